@@ -9,6 +9,12 @@ const TOKEN_CACHE_SIZE = 1000;
 const SESSION_TIMEOUT = 30 * 60 * 1000;
 const MIN_GAME_TIME = 30;
 const MAX_SCORE = 999999;
+const MAX_REPLAY_SIZE = 512 * 1024;
+const TICKS_PER_SECOND = 60;
+const DIFFICULTIES = ['easy', 'normal', 'hard', 'nightmare'];
+const RANKED_MODES = ['classic', 'timeAttack', 'daily'];
+const DAY_MS = 24 * 60 * 60 * 1000;
+const TIME_ATTACK_SECONDS = 180;
 
 let firebaseApp;
 
@@ -25,9 +31,9 @@ function getSecretKey() {
   return key;
 }
 
-function verifySignature(name, score, sessionToken, timestamp, signature) {
+function verifySignature(name, score, mode, difficulty, challenge, sessionToken, timestamp, signature) {
   try {
-    const message = `${name}|${score}|${sessionToken}|${timestamp}`;
+    const message = `${name}|${score}|${mode}|${difficulty}|${challenge}|${sessionToken}|${timestamp}`;
     const hmac = crypto.createHmac('sha256', getSecretKey());
     hmac.update(message);
     const expectedSignature = hmac.digest('hex');
@@ -154,6 +160,91 @@ function validateScore(name, score, sessionToken) {
   return { valid: true };
 }
 
+// Classic on Normal keeps the original path so scores from before
+// difficulties and modes existed stay on its board.
+function leaderboardPath(mode, difficulty, challenge) {
+  if (mode === 'daily') {
+    return `leaderboard_daily_${challenge}`;
+  }
+  if (mode === 'timeAttack') {
+    return `leaderboard_timeAttack_${difficulty}`;
+  }
+  return difficulty === 'normal' ? 'leaderboard' : `leaderboard_${difficulty}`;
+}
+
+function isValidDifficulty(difficulty) {
+  return typeof difficulty === 'string' && DIFFICULTIES.includes(difficulty);
+}
+
+function isValidMode(mode) {
+  return typeof mode === 'string' && RANKED_MODES.includes(mode);
+}
+
+function utcDate(time) {
+  return new Date(time).toISOString().slice(0, 10);
+}
+
+// Daily runs name their UTC date. A run started just before midnight may
+// finish the next day, so yesterday's challenge is still accepted.
+function isValidChallenge(mode, challenge, now = Date.now()) {
+  if (mode !== 'daily') {
+    return challenge === '';
+  }
+  return challenge === utcDate(now) || challenge === utcDate(now - DAY_MS);
+}
+
+function validateReplay(replayData, score, mode, difficulty, challenge) {
+  if (typeof replayData !== 'string' || replayData.length === 0) {
+    return { valid: false, error: 'Missing replay' };
+  }
+
+  if (replayData.length > MAX_REPLAY_SIZE) {
+    return { valid: false, error: 'Replay too large' };
+  }
+
+  let replay;
+  try {
+    replay = JSON.parse(replayData);
+  } catch (error) {
+    return { valid: false, error: 'Invalid replay' };
+  }
+
+  if (replay.version !== API_VERSION) {
+    return { valid: false, error: 'Replay version mismatch' };
+  }
+
+  if (replay.finalScore !== score) {
+    return { valid: false, error: 'Replay score does not match' };
+  }
+
+  if (replay.difficulty !== difficulty) {
+    return { valid: false, error: 'Replay difficulty does not match' };
+  }
+
+  if ((replay.mode || 'classic') !== mode) {
+    return { valid: false, error: 'Replay mode does not match' };
+  }
+
+  if ((replay.daily || '') !== challenge) {
+    return { valid: false, error: 'Replay challenge does not match' };
+  }
+
+  if (!Number.isInteger(replay.ticks) || replay.ticks <= 0 || typeof replay.frames !== 'string') {
+    return { valid: false, error: 'Invalid replay frames' };
+  }
+
+  const replaySeconds = replay.ticks / TICKS_PER_SECOND;
+  if (replaySeconds < MIN_GAME_TIME) {
+    return { valid: false, error: 'Replay too short' };
+  }
+
+  if (mode === 'timeAttack' && replay.ticks !== TIME_ATTACK_SECONDS * TICKS_PER_SECOND) {
+    return { valid: false, error: 'Replay length does not match Time Attack' };
+  }
+
+  return { valid: true };
+}
+
 const rateLimitMap = new Map();
 const usedTokens = new Map();
 
@@ -214,20 +305,38 @@ export default async function handler(req, res) {
   
   try {
     if (req.method === 'GET') {
+      const difficulty = req.query?.difficulty || 'normal';
+      if (!isValidDifficulty(difficulty)) {
+        return res.status(400).json({ error: 'Invalid difficulty' });
+      }
+      
+      const mode = req.query?.mode || 'classic';
+      if (!isValidMode(mode)) {
+        return res.status(400).json({ error: 'Invalid mode' });
+      }
+      
+      const challenge = mode === 'daily' ? (req.query?.challenge || utcDate(Date.now())) : '';
+      if (!isValidChallenge(mode, challenge)) {
+        return res.status(400).json({ error: 'Invalid challenge' });
+      }
+      
       const db = initializeFirebase();
-      const snapshot = await db.ref('leaderboard').orderByChild('score').limitToLast(10).once('value');
+      const snapshot = await db.ref(leaderboardPath(mode, difficulty, challenge)).orderByChild('score').limitToLast(10).once('value');
       
       const leaderboard = [];
       snapshot.forEach((child) => {
         leaderboard.push({
           id: child.key,
+          difficulty,
+          mode,
+          challenge,
           ...child.val()
         });
       });
       
       leaderboard.sort((a, b) => b.score - a.score);
       
-      return res.status(200).json({ leaderboard });
+      return res.status(200).json({ leaderboard, difficulty, mode, challenge });
       
     } else if (req.method === 'POST') {
       
@@ -235,7 +344,24 @@ export default async function handler(req, res) {
         return res.status(429).json({ error: 'Too many requests. Please wait.' });
       }
       
-      const { name, score, sessionToken, timestamp, signature, recaptchaToken } = req.body;
+      const { name, score, sessionToken, timestamp, signature, recaptchaToken, replay, difficulty, mode } = req.body;
+      const challenge = req.body.challenge || '';
+      
+      if (!isValidDifficulty(difficulty)) {
+        return res.status(400).json({ error: 'Invalid difficulty' });
+      }
+      
+      if (!isValidMode(mode)) {
+        return res.status(400).json({ error: 'Invalid mode' });
+      }
+      
+      if (!isValidChallenge(mode, challenge)) {
+        return res.status(400).json({ error: 'Invalid challenge' });
+      }
+      
+      if (mode === 'daily' && difficulty !== 'normal') {
+        return res.status(400).json({ error: 'Daily challenges are played on normal' });
+      }
       
       if (!timestamp || typeof timestamp !== 'number') {
         return res.status(400).json({ error: 'Invalid timestamp' });
@@ -252,7 +378,7 @@ export default async function handler(req, res) {
         return res.status(403).json({ error: 'Missing signature' });
       }
       
-      if (!verifySignature(name, score, sessionToken, timestamp, signature)) {
+      if (!verifySignature(name, score, mode, difficulty, challenge, sessionToken, timestamp, signature)) {
         return res.status(403).json({ error: 'Invalid signature' });
       }
       
@@ -278,16 +404,27 @@ export default async function handler(req, res) {
         return res.status(400).json({ error: validation.error });
       }
       
+      const replayValidation = validateReplay(replay, score, mode, difficulty, challenge);
+      if (!replayValidation.valid) {
+        return res.status(400).json({ error: replayValidation.error });
+      }
+      
       const db = initializeFirebase();
-      const newScoreRef = db.ref('leaderboard').push();
+      const boardPath = leaderboardPath(mode, difficulty, challenge);
+      const newScoreRef = db.ref(boardPath).push();
       await newScoreRef.set({
         name: name.trim(),
         score: score,
+        difficulty: difficulty,
+        mode: mode,
         timestamp: admin.database.ServerValue.TIMESTAMP
       });
       
+      // Kept so disputed entries can be re-simulated with core.VerifyReplay.
+      await db.ref('replays').child(newScoreRef.key).set(replay);
+      
       try {
-        const allScoresSnapshot = await db.ref('leaderboard').orderByChild('score').once('value');
+        const allScoresSnapshot = await db.ref(boardPath).orderByChild('score').once('value');
         const allScores = [];
         
         allScoresSnapshot.forEach((child) => {
@@ -301,9 +438,10 @@ export default async function handler(req, res) {
         
         if (allScores.length > MAX_LEADERBOARD_SIZE) {
           const toDelete = allScores.slice(MAX_LEADERBOARD_SIZE);
-          const deletePromises = toDelete.map(entry => 
-            db.ref('leaderboard').child(entry.key).remove()
-          );
+          const deletePromises = toDelete.flatMap(entry => [
+            db.ref(boardPath).child(entry.key).remove(),
+            db.ref('replays').child(entry.key).remove()
+          ]);
           await Promise.all(deletePromises);
         }
       } catch (cleanupError) {
@@ -10,11 +10,9 @@ const (
 	PlayerShootCooldown = time.Millisecond * 500
 	PlayerMaxLives      = 3
 	PlayerMaxExtraLives = 2
-	PlayerMaxTotalLives = 5
 
 	MeteorMinSpeed    = 2.0
 	MeteorMaxSpeed    = 13.0
-	MeteorSpawnTime   = 1 * time.Second
 	MeteorRotationMin = -0.02
 	MeteorRotationMax = 0.02
 
@@ -23,15 +21,12 @@ const (
 	MeteorIceSlowDuration       = 3 * time.Second
 	MeteorExplosiveRadius       = 80.0
 	MeteorExplosiveDamageRadius = 60.0
-	MeteorIceSpawnChance        = 0.08 // 8% chance
-	MeteorExplosiveSpawnChance  = 0.06 // 6% chance
-	MeteorExplosiveSpeed        = 3.5  // Slower than normal meteors
+	MeteorExplosiveSpeed        = 3.5 // Slower than normal meteors
 
 	LaserSpeed      = 7.0
 	SuperLaserSpeed = 12.0
 
 	PowerUpSpeed     = 3.0
-	PowerUpSpawnTime = 20 * time.Second
 	SuperPowerTime   = 10 * time.Second
 	ShieldTime       = 10 * time.Second
 	SlowMotionTime   = 15 * time.Second
@@ -41,8 +36,6 @@ const (
 	NukeClearScreenTime = 5 * time.Second
 	MultiplierTime      = 20 * time.Second
 	MultiplierBonus     = 2.0
-	MinWaveForLaser     = 5
-	MinWaveForNuke      = 5
 
 	StarSpawnTime   = (1 * time.Second) / 2
 	PlanetSpawnTime = 5 * time.Second
@@ -53,7 +46,6 @@ const (
 	ComboTimeout    = 3 * time.Second
 	ComboMultiplier = 0.5
 
-	WaveMeteoIncrease  = 2
 	WaveScoreThreshold = 50
 
 	ParticleLifetime = 30
@@ -63,37 +55,52 @@ const (
 	PlayerDeathAnimationDuration = 90 // 1.5 seconds at 60 FPS
 	PlayerDeathExplosionCount    = 30 // Number of explosion particles
 
+	// CollisionCellSize is the side of a collision grid cell, about the
+	// size of a big meteor.
+	CollisionCellSize = 96
+
 	ScreenShakeDuration   = 10
 	ScreenShakeIntensity  = 8.0
 	ScreenShakeBossHit    = 5
 	ScreenShakeBossDefeat = 20
+	ScreenShakeBossPhase  = 15
 
 	BossWaveInterval          = 5
 	BossScoreThreshold        = 250
 	BossReward                = 100
 	BossCooldownTime          = 60 * time.Second
-	PowerUpSpawnTimeBoss      = 8 * time.Second
 	PostBossInvincibilityTime = 3 * time.Second
-
-	BossTankHealth          = 150
-	BossTankSpeed           = 2.0
-	BossTankShootCooldown   = time.Millisecond * 1000
-	BossTankProjectileSpeed = 4.0
-
-	BossSniperHealth          = 80
-	BossSniperSpeed           = 4.8
-	BossSniperShootCooldown   = time.Millisecond * 600
-	BossSniperProjectileSpeed = 7.0
-
-	BossSwarmHealth          = 100
-	BossSwarmSpeed           = 5.25
-	BossSwarmShootCooldown   = time.Millisecond * 1200
-	BossSwarmProjectileSpeed = 5.0
-	BossMinionHealth         = 8
-	BossMinionSize           = 15.0
-	BossMinionCount          = 2
-	BossSwarmMinionCount     = 3
-	PointsPerMinionKill      = 25
+	// BossPhaseInvulnerability is how long a boss shrugs off hits after
+	// entering a new phase.
+	BossPhaseInvulnerability = 1500 * time.Millisecond
+
+	// Boss Rush: each boss gets tougher than the last
+	BossRushBosses           = 6
+	BossRushIntermission     = 8 * time.Second
+	BossRushHealthStep       = 0.25
+	BossRushCooldownStep     = 0.1
+	BossRushMinCooldownScale = 0.4
+
+	// Time Attack: a fixed-length run where hits cost points, not lives
+	TimeAttackDuration    = 3 * time.Minute
+	TimeAttackHitPenalty  = 50
+	TimeAttackWarningTime = 10 * time.Second
+
+	// Daily Challenge modifiers
+	DailyFastMeteorSpeed  = 1.3
+	DailyBossHealthFactor = 2.0
+
+	// Shop perks
+	CoinMagnetRadius = 160.0
+	CoinMagnetSpeed  = 5.0
+
+	// Co-op: ships start this far either side of the screen center
+	CoopShipSpread = 120.0
+
+	// Boss stats and behaviour are in internal/systems/bosses.json.
+	BossMinionHealth    = 8
+	BossMinionSize      = 15.0
+	PointsPerMinionKill = 25
 
 	// UI Constants
 	PauseIconSize   = 30
@@ -114,18 +121,12 @@ const (
 	BossAnnouncementFade = 30
 
 	// Game Mechanics
-	BossTypesCount             = 3
-	MeteorsPerWaveOffset       = 1
-	WaveMeteoIncrement         = 5
-	WaveDifficultyFactor       = 0.15
-	BossWarningShakeTime       = 30
-	BossScoreProximity         = 10
-	BossAnnouncementTime       = 120
-	ExplosionParticlesMul      = 3
-	MinionParticles            = 3
-	BossProjectileOffsetY      = 40
-	BossSwarmProjectileOffsetX = 30
-	BossShootMinY              = 100
-	InitialCapacityLasers      = 100
-	InitialCapacityParticles   = 100
+	BossWarningShakeTime     = 30
+	BossScoreProximity       = 10
+	BossAnnouncementTime     = 120
+	ExplosionParticlesMul    = 3
+	MinionParticles          = 3
+	BossShootMinY            = 100
+	InitialCapacityLasers    = 100
+	InitialCapacityParticles = 100
 )
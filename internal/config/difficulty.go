@@ -0,0 +1,88 @@
+package config
+
+type Difficulty int
+
+const (
+	DifficultyEasy Difficulty = iota
+	DifficultyNormal
+	DifficultyHard
+	DifficultyNightmare
+	DifficultyCount
+)
+
+// DifficultyPreset scales the base balance values. Rates above 1 mean
+// more often: meteors spawn SpawnRate times as often as the wave table says.
+type DifficultyPreset struct {
+	MeteorSpeed float64
+	SpawnRate   float64
+	BossHealth  float64
+	Lives       int
+	PowerUpRate float64
+}
+
+var difficultyPresets = [DifficultyCount]DifficultyPreset{
+	DifficultyEasy: {
+		MeteorSpeed: 0.8,
+		SpawnRate:   0.75,
+		BossHealth:  0.7,
+		Lives:       5,
+		PowerUpRate: 1.5,
+	},
+	DifficultyNormal: {
+		MeteorSpeed: 1.0,
+		SpawnRate:   1.0,
+		BossHealth:  1.0,
+		Lives:       InitialLives,
+		PowerUpRate: 1.0,
+	},
+	DifficultyHard: {
+		MeteorSpeed: 1.2,
+		SpawnRate:   1.3,
+		BossHealth:  1.4,
+		Lives:       2,
+		PowerUpRate: 0.8,
+	},
+	DifficultyNightmare: {
+		MeteorSpeed: 1.45,
+		SpawnRate:   1.7,
+		BossHealth:  2.0,
+		Lives:       1,
+		PowerUpRate: 0.6,
+	},
+}
+
+var difficultyKeys = [DifficultyCount]string{"easy", "normal", "hard", "nightmare"}
+var difficultyNames = [DifficultyCount]string{"Easy", "Normal", "Hard", "Nightmare"}
+
+func (d Difficulty) Preset() DifficultyPreset {
+	if d < 0 || d >= DifficultyCount {
+		d = DifficultyNormal
+	}
+	return difficultyPresets[d]
+}
+
+// Key is the stable name used in saves, replays and leaderboards.
+func (d Difficulty) Key() string {
+	if d < 0 || d >= DifficultyCount {
+		d = DifficultyNormal
+	}
+	return difficultyKeys[d]
+}
+
+func (d Difficulty) String() string {
+	if d < 0 || d >= DifficultyCount {
+		d = DifficultyNormal
+	}
+	return difficultyNames[d]
+}
+
+// DifficultyFromKey parses a Key. Unknown or empty keys are Normal, which is
+// what every run was before difficulties existed.
+func DifficultyFromKey(key string) Difficulty {
+	for i, k := range difficultyKeys {
+		if k == key {
+			return Difficulty(i)
+		}
+	}
+	return DifficultyNormal
+}
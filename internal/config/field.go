@@ -0,0 +1,48 @@
+package config
+
+// Orientation is the shape of the playfield a run is simulated on.
+type Orientation int
+
+const (
+	// Landscape is the ScreenWidth by ScreenHeight field.
+	Landscape Orientation = iota
+	// Portrait is a narrower, taller field for phones held upright. The
+	// extra height gives meteors a longer way down.
+	Portrait
+)
+
+const (
+	PortraitFieldWidth  = 600
+	PortraitFieldHeight = 900
+
+	// ControlStripHeight is the band below a portrait field that holds the
+	// touch controls, hearts and power-up bars.
+	ControlStripHeight = 220
+)
+
+// orientation is shared by everything that places or bounds entities. It
+// only changes between runs, so a run is simulated on one field throughout.
+var orientation = Landscape
+
+func FieldOrientation() Orientation {
+	return orientation
+}
+
+func SetFieldOrientation(o Orientation) {
+	orientation = o
+}
+
+// FieldWidth and FieldHeight are the size of the current playfield.
+func FieldWidth() float64 {
+	if orientation == Portrait {
+		return PortraitFieldWidth
+	}
+	return ScreenWidth
+}
+
+func FieldHeight() float64 {
+	if orientation == Portrait {
+		return PortraitFieldHeight
+	}
+	return ScreenHeight
+}
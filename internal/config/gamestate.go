@@ -13,12 +13,8 @@ const (
 	StateBossFight
 	StatePlayerDeath
 	StateWaitingNameInput
+	StateReplay
 )
 
+// BossType is a boss's index in the boss book, systems.BossBook.
 type BossType int
-
-const (
-	BossTank BossType = iota
-	BossSniper
-	BossSwarm
-)
@@ -0,0 +1,40 @@
+package config
+
+type GameMode int
+
+const (
+	ModeClassic GameMode = iota
+	ModeBossRush
+	ModeTimeAttack
+	ModeDaily
+	ModeCoop
+	ModeCoopSplit
+	ModeCount
+)
+
+var modeKeys = [ModeCount]string{"classic", "bossRush", "timeAttack", "daily", "coop", "coopSplit"}
+var modeNames = [ModeCount]string{"Classic", "Boss Rush", "Time Attack", "Daily Challenge", "Co-op (Shared Lives)", "Co-op (Own Lives)"}
+
+// Key is the stable name used in saves, replays and leaderboards.
+func (m GameMode) Key() string {
+	if m < 0 || m >= ModeCount {
+		m = ModeClassic
+	}
+	return modeKeys[m]
+}
+
+func (m GameMode) String() string {
+	if m < 0 || m >= ModeCount {
+		m = ModeClassic
+	}
+	return modeNames[m]
+}
+
+func GameModeFromKey(key string) GameMode {
+	for i, k := range modeKeys {
+		if k == key {
+			return GameMode(i)
+		}
+	}
+	return ModeClassic
+}
@@ -0,0 +1,16 @@
+package core
+
+import "testing"
+
+// The systems tests check the book against stand-in sprite names; this
+// checks it against the ones the game actually draws.
+func TestEmbeddedBossBookLoads(t *testing.T) {
+	defer func() {
+		if err := recover(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if book := loadBosses(); len(book.Bosses) == 0 {
+		t.Fatal("no bosses")
+	}
+}
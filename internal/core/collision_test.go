@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/systems"
+	assets "go-meteor/src/pkg"
+)
+
+// collisionScene fills a fresh run with meteors and lasers scattered over
+// the field, keeping only lasers that miss every meteor, so each pass of
+// checkLaserMeteorCollisions does the same work and changes nothing.
+func collisionScene(meteors, lasers int) *Game {
+	g := NewHeadless(1).Game()
+	rng := rand.New(rand.NewSource(1))
+	for range meteors {
+		m := g.meteorPool.Get()
+		m.Reset(rng, 1, systems.MeteorMix{})
+		m.Restore(entities.MeteorState{
+			Position: systems.Vector{X: rng.Float64() * config.FieldWidth(), Y: rng.Float64() * config.FieldHeight()},
+			Rotation: rng.Float64() * 6,
+			Sprite:   rng.Intn(len(assets.MeteorSprites)),
+		})
+		g.meteors = append(g.meteors, m)
+	}
+	for len(g.lasers) < lasers {
+		pos := systems.Vector{X: rng.Float64() * config.FieldWidth(), Y: rng.Float64() * config.FieldHeight()}
+		l := entities.NewLaser(pos, false, false)
+		hit := false
+		for _, m := range g.meteors {
+			hit = hit || systems.Overlaps(m.Hitbox(), l.Hitbox())
+		}
+		if !hit {
+			g.lasers = append(g.lasers, l)
+		}
+	}
+	return g
+}
+
+func TestLaserMeteorPassDoesNotAllocate(t *testing.T) {
+	g := collisionScene(40, 30)
+	g.checkLaserMeteorCollisions()
+	allocs := testing.AllocsPerRun(50, g.checkLaserMeteorCollisions)
+	if allocs != 0 {
+		t.Errorf("a pass without hits allocates %v times", allocs)
+	}
+	if len(g.meteors) != 40 || len(g.lasers) != 30 {
+		t.Fatalf("scene changed: %d meteors, %d lasers", len(g.meteors), len(g.lasers))
+	}
+}
+
+func TestCleanupsKeepSurvivorsInOrder(t *testing.T) {
+	g := collisionScene(6, 0)
+	kept := []*entities.Meteor{g.meteors[0], g.meteors[2], g.meteors[5]}
+	for _, i := range []int{1, 3, 4} {
+		s := g.meteors[i].State()
+		s.Position.Y = config.FieldHeight() + 200
+		g.meteors[i].Restore(s)
+	}
+	g.cleanMeteors()
+	if len(g.meteors) != len(kept) {
+		t.Fatalf("%d meteors left, want %d", len(g.meteors), len(kept))
+	}
+	for i := range kept {
+		if g.meteors[i] != kept[i] {
+			t.Errorf("meteor %d is not the one expected", i)
+		}
+	}
+}
+
+// BenchmarkLaserMeteorCollisions measures the shipped laser-meteor pass at
+// wave sizes from early game to a crowded late wave.
+func BenchmarkLaserMeteorCollisions(b *testing.B) {
+	for _, n := range [][2]int{{20, 10}, {60, 40}, {120, 80}} {
+		g := collisionScene(n[0], n[1])
+		b.Run(fmt.Sprintf("%dx%d", n[0], n[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				g.checkLaserMeteorCollisions()
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/systems"
+)
+
+func coopGame() *Game {
+	g := NewHeadless(3).Game()
+	g.mode = config.ModeCoop
+	g.beginSession()
+	return g
+}
+
+func TestShipsSkipsKnockedOut(t *testing.T) {
+	g := coopGame()
+	one, two := g.player, g.coop.partner
+
+	tests := []struct {
+		knockedOut [2]bool
+		want       []*entities.Player
+	}{
+		{[2]bool{false, false}, []*entities.Player{one, two}},
+		{[2]bool{true, false}, []*entities.Player{two}},
+		{[2]bool{false, true}, []*entities.Player{one}},
+		{[2]bool{true, true}, nil},
+	}
+	for _, tt := range tests {
+		g.coop.knockedOut = tt.knockedOut
+		if got := g.ships(); !slices.Equal(got, tt.want) {
+			t.Errorf("knocked out %v: ships() = %v, want %v", tt.knockedOut, got, tt.want)
+		}
+	}
+}
+
+func TestShipsDoesNotAllocate(t *testing.T) {
+	for name, g := range map[string]*Game{"solo": NewHeadless(3).Game(), "coop": coopGame()} {
+		if allocs := testing.AllocsPerRun(100, func() { g.ships() }); allocs != 0 {
+			t.Errorf("%s: ships() allocates %v times", name, allocs)
+		}
+	}
+}
+
+func TestNearestShip(t *testing.T) {
+	g := coopGame()
+	one, two := g.player, g.coop.partner
+	c := two.Collider()
+	nearTwo := systems.Vector{X: c.X, Y: c.Y}
+
+	if ship, ok := g.nearestShip(nearTwo); !ok || ship != two {
+		t.Errorf("nearest to the partner is %v, %v", ship, ok)
+	}
+	g.coop.knockedOut = [2]bool{false, true}
+	if ship, ok := g.nearestShip(nearTwo); !ok || ship != one {
+		t.Errorf("with the partner out, nearest is %v, %v", ship, ok)
+	}
+	g.coop.knockedOut = [2]bool{true, true}
+	if ship, ok := g.nearestShip(nearTwo); ok || ship != nil {
+		t.Errorf("with both out, nearest is %v, %v", ship, ok)
+	}
+}
+
+// A boss fight carries on, without aiming, while both ships are down.
+func TestBossFightWithNoShipInPlay(t *testing.T) {
+	g := coopGame()
+	g.coop.knockedOut = [2]bool{true, true}
+	for i := range g.bosses.Bosses {
+		g.boss = entities.NewBoss(g.rng, g.bosses, config.BossType(i), g.bossScaling())
+		for range 600 {
+			g.updateBossAndMinions()
+			g.updateBossFightObjects()
+		}
+	}
+	if len(g.bossProjectiles) == 0 {
+		t.Error("bosses held fire with no ship in play")
+	}
+}
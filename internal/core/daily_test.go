@@ -0,0 +1,24 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"go-meteor/internal/systems"
+)
+
+func TestDailyMenuInfoCachedPerDate(t *testing.T) {
+	g := NewHeadless(1).Game()
+	info := g.dailyMenuInfo()
+	cached := g.menuDaily
+	if g.dailyMenuInfo() != info || g.menuDaily != cached {
+		t.Fatal("challenge rebuilt on the same date")
+	}
+
+	g.menuDaily.Date = "2000-01-01"
+	g.dailyMenuInfo()
+	today := systems.DailyDate(time.Now())
+	if g.menuDaily == cached || g.menuDaily.Date != today {
+		t.Errorf("challenge for %q kept after the date changed to %q", g.menuDaily.Date, today)
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"image/color"
+	"math/rand"
 	"time"
 
 	"go-meteor/internal/config"
@@ -16,6 +17,11 @@ import (
 
 var emptyImage = ebiten.NewImage(1, 1)
 
+const GameVersion = "0.2.0"
+
+// fxSeedSalt derives the cosmetic random stream from the run seed.
+const fxSeedSalt = 0x5eed_f00d
+
 func init() {
 	emptyImage.Fill(color.White)
 }
@@ -33,6 +39,7 @@ type Game struct {
 	pauseMenu         *ui.PauseMenu
 	settingsMenu      *ui.Settings
 	notification      *ui.Notification
+	padNotice         *ui.Notification
 	shop              *ui.Shop
 
 	player           *entities.Player
@@ -68,6 +75,13 @@ type Game struct {
 	powerUpPool        *entities.PowerUpPool
 	bossProjectilePool *entities.BossProjectilePool
 
+	// grid narrows each collision pass to things near each other. It and
+	// the buffers below are refilled every pass rather than reallocated.
+	grid        *systems.SpatialHash
+	gridHits    []int
+	meteorFlags []bool
+	laserFlags  []bool
+
 	coins []*entities.Coin
 
 	score int
@@ -79,11 +93,70 @@ type Game struct {
 	playerDeathExplosionX float64
 	playerDeathExplosionY float64
 
+	inputSource   input.Source
+	partnerSource input.Source
+	controls      input.Controls
+	headless      bool
+	// showHitboxes draws collision shapes over the field, toggled on F3.
+	showHitboxes bool
+
+	// Every gameplay roll in a run comes from rng, seeded once per run, so
+	// the same seed and inputs produce the same game. Stars and particles
+	// use fxRng so cosmetic differences (fewer particles on mobile) never
+	// shift the gameplay sequence.
+	seed        int64
+	rng         *rand.Rand
+	rngSource   *countingSource
+	fxRng       *rand.Rand
+	pendingSeed *int64
+
+	// recorder captures the current run; playback is set on games that
+	// re-simulate a recorded run instead of reading live input.
+	recorder    *systems.Replay
+	playback    *systems.Replay
+	lastReplay  *systems.Replay
+	viewer      *replayViewer
+	runUpgrades map[string]int
+	difficulty  config.Difficulty
+	mode        config.GameMode
+	waves       *systems.WaveTable
+	bosses      *systems.BossBook
+
+	pausedRunState  config.GameState
+	hasSuspendedRun bool
+
+	// runTicks counts simulated run ticks; Boss Rush is scored on it.
+	runTicks        int
+	bossRushCleared bool
+
+	timeAttackHits    int
+	timeAttackPenalty int
+
+	daily       *systems.DailyChallenge
+	dailyScored bool
+	// menuDaily is today's challenge as the menu shows it, kept until the
+	// date changes rather than rebuilt every tick.
+	menuDaily     *systems.DailyChallenge
+	menuDailyText string
+
+	// coop is nil outside of co-op runs; solo backs ships() then.
+	coop *coopState
+	solo [1]*entities.Player
+
 	joystick      *input.Joystick
 	shootButton   *input.ShootButton
 	isMobile      bool
 	touchDetected bool
 
+	// field is sized to the playfield, canvas to the menus and hud to the
+	// view. See package display.
+	field  *ebiten.Image
+	canvas *ebiten.Image
+	hud    *ebiten.Image
+
+	// gamepads are the pads connected last tick, to spot unplugged ones.
+	gamepads []ebiten.GamepadID
+
 	pauseIconX int
 	pauseIconY int
 
@@ -101,12 +174,26 @@ type Game struct {
 	statistics        *ui.Statistics
 }
 
+// NewGame creates the interactive game. Only it applies the saved settings:
+// volumes, bindings, touch layout and display options are process-wide, so
+// the headless and replay games built with newGame must leave them alone.
 func NewGame() *Game {
+	g := newGame(systems.NewStorage(), input.NewKeyboardSource())
+	g.loadSettings()
+	g.partnerSource = input.NewPartnerSource()
+	g.registerSuspendHandler()
+	return g
+}
+
+func newGame(storage systems.Storage, source input.Source) *Game {
+	waves := loadWaves()
 	g := &Game{
 		state:                      config.StateMenu,
-		meteoSpawnTimer:            systems.NewTimer(config.MeteorSpawnTime),
+		waves:                      waves,
+		bosses:                     loadBosses(),
+		meteoSpawnTimer:            systems.NewTimer(waves.MeteorsAt(1).SpawnInterval),
 		starSpawnTimer:             systems.NewTimer(config.StarSpawnTime),
-		powerUpSpawnTimer:          systems.NewTimer(config.PowerUpSpawnTime),
+		powerUpSpawnTimer:          systems.NewTimer(waves.PowerUpsAt(1).SpawnInterval()),
 		superPowerTimer:            systems.NewTimer(config.SuperPowerTime),
 		comboTimer:                 systems.NewTimer(config.ComboTimeout),
 		bossCooldownTimer:          systems.NewTimer(config.BossCooldownTime),
@@ -125,12 +212,17 @@ func NewGame() *Game {
 		laserPool:                  entities.NewLaserPool(),
 		powerUpPool:                entities.NewPowerUpPool(),
 		bossProjectilePool:         entities.NewBossProjectilePool(),
+		grid:                       systems.NewSpatialHash(config.CollisionCellSize),
 		notification:               ui.NewNotification(),
+		padNotice:                  ui.NewNotificationAt(padNoticeY),
 		wave:                       1,
+		difficulty:                 config.DifficultyNormal,
 		isMobile:                   false,
 		touchDetected:              false,
 		leaderboard:                systems.NewLeaderboard(),
-		storage:                    systems.NewStorage(),
+		storage:                    storage,
+		inputSource:                source,
+		partnerSource:              input.NewInjectedSource(),
 		meteors:                    make([]*entities.Meteor, 0, 50),
 		stars:                      make([]*entities.Star, 0, 50),
 		lasers:                     make([]*entities.Laser, 0, config.InitialCapacityLasers),
@@ -145,6 +237,7 @@ func NewGame() *Game {
 		pauseIconY:                 config.PauseIconMargin,
 	}
 
+	g.seedRun()
 	g.player = entities.NewPlayer(g)
 	g.menu = ui.NewMenu()
 	g.pauseMenu = ui.NewPauseMenu()
@@ -155,6 +248,8 @@ func NewGame() *Game {
 	g.loadHighScore()
 	g.loadLeaderboard()
 	g.loadProgress()
+	g.loadReplay()
+	g.hasSuspendedRun = g.loadSuspendedRun() != nil
 
 	if g.progress != nil {
 		g.player.SetSkin(g.progress.EquippedSkin)
@@ -162,3 +257,78 @@ func NewGame() *Game {
 
 	return g
 }
+
+func (g *Game) beginSession() {
+	g.setOrientation(g.runOrientation())
+	g.player.MoveToStart()
+	g.meteorsDestroyed = 0
+	g.powerUpsCollected = 0
+	g.gameStartTime = time.Now()
+	g.survivalTime = 0
+	g.prepareDaily()
+	g.seedRun()
+	g.startRecording()
+	g.player.SetBaseLives(g.difficulty.Preset().Lives)
+	g.setupCoop()
+	g.applyStartPerks()
+	g.runTicks = 0
+	g.bossRushCleared = false
+	g.timeAttackHits = 0
+	g.timeAttackPenalty = 0
+	g.bossCooldownTimer = systems.NewTimer(g.bossCooldownTime())
+}
+
+// seedRun reseeds the run's random sources. A seed queued with queueSeed is
+// used once; otherwise a fresh one is taken from the clock.
+func (g *Game) seedRun() {
+	seed := time.Now().UnixNano()
+	if g.pendingSeed != nil {
+		seed = *g.pendingSeed
+		g.pendingSeed = nil
+	}
+
+	g.seed = seed
+	g.rngSource = newCountingSource(seed)
+	g.rng = rand.New(g.rngSource)
+	g.fxRng = rand.New(rand.NewSource(seed ^ fxSeedSalt))
+}
+
+func (g *Game) queueSeed(seed int64) {
+	g.pendingSeed = &seed
+}
+
+func (g *Game) Seed() int64 {
+	return g.seed
+}
+
+// countingSource counts draws so a suspended run can bring a freshly seeded
+// source back to the same position.
+type countingSource struct {
+	src   rand.Source64
+	draws uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.draws = 0
+}
+
+func (s *countingSource) skip(draws uint64) {
+	for s.draws < draws {
+		s.Uint64()
+	}
+}
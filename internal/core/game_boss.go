@@ -2,8 +2,7 @@ package core
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
+	"sync"
 
 	"go-meteor/internal/config"
 	"go-meteor/internal/entities"
@@ -16,11 +15,28 @@ import (
 
 // Boss management functions
 
+// loadBosses reads the boss book once per process. It is embedded, so a
+// broken one is a build mistake.
+var loadBosses = sync.OnceValue(func() *systems.BossBook {
+	book, err := systems.LoadBossBook(systems.BossSprites{
+		Boss:       entities.IsBossSprite,
+		Projectile: entities.IsProjectileSprite,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return book
+})
+
 func (g *Game) shouldSpawnBoss() bool {
 	if g.boss != nil || g.bossDefeated {
 		return false
 	}
 
+	if g.isBossRush() {
+		return !g.bossWarningShown
+	}
+
 	return (g.wave > 0 && g.wave%config.BossWaveInterval == 0 && !g.bossWarningShown) ||
 		(g.score >= config.BossScoreThreshold && g.score < config.BossScoreThreshold+config.BossScoreProximity && !g.bossWarningShown)
 }
@@ -30,7 +46,7 @@ func (g *Game) spawnBoss() {
 	g.screenShake = config.BossWarningShakeTime
 	g.bossAnnouncementTimer = config.BossAnnouncementTime
 	g.state = config.StateBossAnnouncement
-	assets.PlayExplosionSound()
+	assets.PlayExplosionSound(config.FieldWidth() / 2)
 }
 
 func (g *Game) updateBossAnnouncement() error {
@@ -63,16 +79,16 @@ func (g *Game) updateBossAnnouncement() error {
 
 	g.cleanObjects()
 
-	g.player.Update()
+	g.updateShips()
 	g.notification.Update()
 
 	if g.bossAnnouncementTimer <= 0 {
-		bossType := config.BossType(rand.Intn(config.BossTypesCount))
-		g.boss = entities.NewBoss(bossType)
+		bossType := config.BossType(g.rng.Intn(len(g.bosses.Bosses)))
+		g.boss = entities.NewBoss(g.rng, g.bosses, bossType, g.bossScaling())
 		g.bossNoDamage = true
 		g.bossBar.Show()
 		g.state = config.StateBossFight
-		g.powerUpSpawnTimer = systems.NewTimer(config.PowerUpSpawnTimeBoss)
+		g.powerUpSpawnTimer = systems.NewTimer(g.powerUpInterval(g.waves.BossPowerUpsAt(g.wave)))
 	}
 
 	return nil
@@ -80,15 +96,14 @@ func (g *Game) updateBossAnnouncement() error {
 
 func (g *Game) updateBossFight() error {
 	if g.shouldPause() {
-		g.stateBeforePause = config.StateBossFight
-		g.state = config.StatePaused
+		g.pause(config.StateBossFight)
 		return nil
 	}
 
 	touchIDs := ebiten.AppendTouchIDs(nil)
 	g.handleMobileControls(touchIDs)
 
-	g.player.Update()
+	g.updateShips()
 	g.notification.Update()
 	g.updateGameTimers()
 
@@ -98,7 +113,7 @@ func (g *Game) updateBossFight() error {
 	g.checkBossCollisions()
 	g.cleanBossObjects()
 
-	g.player.UpdateTimers()
+	g.updateShipTimers()
 	g.screenShake = max(0, g.screenShake-1)
 
 	return nil
@@ -109,83 +124,56 @@ func (g *Game) updateBossAndMinions() {
 		return
 	}
 
-	playerPos := g.player.Collider()
-	g.boss.SetPlayerPosition(systems.Vector{X: playerPos.X, Y: playerPos.Y})
+	// With no ship in play the boss and minions keep their last target.
+	if ship, ok := g.nearestShip(g.boss.GetPosition()); ok {
+		playerPos := ship.Collider()
+		g.boss.SetPlayerPosition(systems.Vector{X: playerPos.X, Y: playerPos.Y})
+	}
 	g.boss.Update()
 
-	g.updateMinions(playerPos)
+	g.updateMinions()
 	g.handleBossShooting()
 }
 
-func (g *Game) updateMinions(playerPos systems.Rect) {
+func (g *Game) updateMinions() {
 	for _, minion := range g.boss.GetMinions() {
 		if minion == nil {
 			continue
 		}
 
-		minion.SetTarget(systems.Vector{X: playerPos.X, Y: playerPos.Y})
+		if ship, ok := g.nearestShip(minion.GetPosition()); ok {
+			playerPos := ship.Collider()
+			minion.SetTarget(systems.Vector{X: playerPos.X, Y: playerPos.Y})
+		}
 		minion.Update()
 
-		if minion.CanShoot() {
-			minion.Shoot()
-			minionPos := minion.GetPosition()
-			bp := g.bossProjectilePool.Get()
-			const projectileYOffset = 10.0
-			bp.Reset(minionPos.X, minionPos.Y+projectileYOffset)
-			g.bossProjectiles = append(g.bossProjectiles, bp)
-		}
+		g.launchBossShots(minion.Fire())
 	}
 }
 
 func (g *Game) handleBossShooting() {
-	if !g.boss.CanShoot() || g.boss.GetPosition().Y < config.BossShootMinY {
+	shots := g.boss.Fire()
+	if len(shots) == 0 {
 		return
 	}
 
-	g.boss.Shoot()
-	pos := g.boss.GetPosition()
-
-	if g.boss.GetBossType() == config.BossSwarm {
-		g.spawnSwarmProjectiles(pos)
-	} else {
-		g.spawnSingleProjectile(pos)
-	}
-
-	assets.PlayExplosionSound()
-}
-
-func (g *Game) spawnSwarmProjectiles(pos systems.Vector) {
-	bp1 := g.bossProjectilePool.Get()
-	bp1.Reset(pos.X-config.BossSwarmProjectileOffsetX, pos.Y+config.BossProjectileOffsetY)
-	g.bossProjectiles = append(g.bossProjectiles, bp1)
-
-	bp2 := g.bossProjectilePool.Get()
-	bp2.Reset(pos.X+config.BossSwarmProjectileOffsetX, pos.Y+config.BossProjectileOffsetY)
-	g.bossProjectiles = append(g.bossProjectiles, bp2)
+	g.launchBossShots(shots)
+	assets.PlayExplosionSound(g.boss.GetPosition().X)
 }
 
-func (g *Game) spawnSingleProjectile(pos systems.Vector) {
-	bp := g.bossProjectilePool.Get()
-	bp.Reset(pos.X, pos.Y+config.BossProjectileOffsetY)
-	g.bossProjectiles = append(g.bossProjectiles, bp)
+func (g *Game) launchBossShots(shots []entities.BossShot) {
+	for _, shot := range shots {
+		bp := g.bossProjectilePool.Get()
+		bp.Launch(shot)
+		g.bossProjectiles = append(g.bossProjectiles, bp)
+	}
 }
 
 func (g *Game) spawnBossPowerUps() {
+	powerUps := g.waves.BossPowerUpsAt(g.wave)
+	g.powerUpSpawnTimer.SetDuration(g.powerUpInterval(powerUps))
 	g.updateAndSpawn(g.powerUpSpawnTimer, func() {
-		var p *entities.PowerUp
-
-		if g.wave >= config.MinWaveForLaser && rand.Float64() < 0.60 {
-			powerType := entities.PowerUpLaser
-			if rand.Float64() < 0.5 {
-				powerType = entities.PowerUpNuke
-			}
-			p = entities.NewPowerUpWithType(powerType)
-		} else {
-			p = g.powerUpPool.Get()
-			p.Reset()
-		}
-
-		g.powerUps = append(g.powerUps, p)
+		g.spawnPowerUpFrom(powerUps)
 	})
 }
 
@@ -194,6 +182,12 @@ func (g *Game) updateBossFightObjects() {
 		pu.Update()
 	}
 	for _, bp := range g.bossProjectiles {
+		if bp.IsHoming() {
+			if ship, ok := g.nearestShip(bp.GetPosition()); ok {
+				target := ship.Collider()
+				bp.SetTarget(systems.Vector{X: target.X, Y: target.Y})
+			}
+		}
 		bp.Update()
 	}
 	for _, l := range g.lasers {
@@ -215,12 +209,31 @@ func (g *Game) checkBossCollisions() {
 	g.checkMinionPlayerCollision()
 }
 
+// checkLaserBossCollisions only tests lasers near the boss or a minion.
+// Lasers are removed from the back as they hit, so the flags of those
+// still to test keep their indices.
 func (g *Game) checkLaserBossCollisions() {
 	if g.boss == nil {
 		return
 	}
+	near := resetFlags(g.laserFlags, len(g.lasers))
+	g.laserFlags = near
+	g.fillGrid(len(g.lasers), func(i int) systems.Rect {
+		return g.lasers[i].Hitbox().Bounds()
+	})
+	for _, i := range g.nearby(g.boss.Hitbox().Bounds()) {
+		near[i] = true
+	}
+	for _, m := range g.boss.GetMinions() {
+		if m != nil {
+			for _, i := range g.nearby(m.Hitbox().Bounds()) {
+				near[i] = true
+			}
+		}
+	}
+
 	for i := len(g.lasers) - 1; i >= 0; i-- {
-		if i >= len(g.lasers) {
+		if i >= len(g.lasers) || !near[i] {
 			continue
 		}
 		if g.checkLaserHitBoss(i) {
@@ -231,11 +244,13 @@ func (g *Game) checkLaserBossCollisions() {
 }
 
 func (g *Game) checkLaserHitBoss(laserIdx int) bool {
-	if !g.lasers[laserIdx].Collider().Intersects(g.boss.Collider()) {
+	if !systems.Overlaps(g.lasers[laserIdx].Hitbox(), g.boss.Hitbox()) {
 		return false
 	}
 
 	damage := g.lasers[laserIdx].GetDamage()
+	owner := g.lasers[laserIdx].Owner()
+	phase := g.boss.Phase()
 	isDead := g.boss.TakeDamage(damage)
 
 	if !g.lasers[laserIdx].IsLaserBeam() {
@@ -245,15 +260,28 @@ func (g *Game) checkLaserHitBoss(laserIdx int) bool {
 	}
 
 	g.addScreenShake(config.ScreenShakeBossHit)
-	assets.PlayExplosionSound()
+	assets.PlayExplosionSound(g.boss.GetPosition().X)
 
 	if isDead {
-		g.defeatBoss()
+		g.defeatBoss(owner)
 		return true
 	}
+	if g.boss.Phase() != phase {
+		g.announceBossPhase()
+	}
 	return false
 }
 
+// announceBossPhase marks the boss moving into a new phase with a burst,
+// a heavier shake, a low alarm and a banner.
+func (g *Game) announceBossPhase() {
+	pos := g.boss.GetPosition()
+	g.createExplosion(pos, config.ParticleCount*2)
+	g.addScreenShake(config.ScreenShakeBossPhase)
+	assets.PlayBossPhaseSound(pos.X)
+	g.notification.Show(fmt.Sprintf("PHASE %d!", g.boss.Phase()+1), ui.NotificationWarning)
+}
+
 func (g *Game) checkLaserHitMinions(laserIdx int) {
 	if g.boss == nil || laserIdx >= len(g.lasers) {
 		return
@@ -263,11 +291,12 @@ func (g *Game) checkLaserHitMinions(laserIdx int) {
 		return
 	}
 	for mIdx, minion := range minions {
-		if minion == nil || laserIdx >= len(g.lasers) || !g.lasers[laserIdx].Collider().Intersects(minion.Collider()) {
+		if minion == nil || laserIdx >= len(g.lasers) || !systems.Overlaps(g.lasers[laserIdx].Hitbox(), minion.Hitbox()) {
 			continue
 		}
 
 		damage := g.lasers[laserIdx].GetDamage()
+		owner := g.lasers[laserIdx].Owner()
 		isDead := minion.TakeDamage(damage)
 
 		if !g.lasers[laserIdx].IsLaserBeam() {
@@ -276,10 +305,10 @@ func (g *Game) checkLaserHitMinions(laserIdx int) {
 			g.lasers = append(g.lasers[:laserIdx], g.lasers[laserIdx+1:]...)
 		}
 
-		assets.PlayExplosionSound()
+		assets.PlayExplosionSound(minion.GetPosition().X)
 
 		if isDead {
-			g.score += config.PointsPerMinionKill
+			g.creditScore(owner, config.PointsPerMinionKill)
 			g.boss.RemoveMinion(mIdx)
 		}
 		break
@@ -287,23 +316,29 @@ func (g *Game) checkLaserHitMinions(laserIdx int) {
 }
 
 func (g *Game) checkPowerUpCollisionsBoss() {
-	for i := len(g.powerUps) - 1; i >= 0; i-- {
-		if g.powerUps[i].Collider().Intersects(g.player.Collider()) {
-			powerType := g.powerUps[i].GetType()
-			g.powerUpPool.Put(g.powerUps[i])
-			g.powerUps = append(g.powerUps[:i], g.powerUps[i+1:]...)
-
-			g.handlePowerUpCollected(powerType)
-			assets.PlayPowerUpSound()
-			break
-		}
+	for _, p := range g.ships() {
+		g.checkPowerUpPickup(p)
 	}
 }
 
 func (g *Game) checkBossProjectileCollisions() {
-	for i := len(g.bossProjectiles) - 1; i >= 0; i-- {
-		if g.bossProjectiles[i].Collider().Intersects(g.player.Collider()) {
-			isDead := g.player.TakeDamage()
+	for _, p := range g.ships() {
+		if g.checkBossProjectileHit(p) {
+			return
+		}
+	}
+}
+
+func (g *Game) checkBossProjectileHit(p *entities.Player) bool {
+	g.fillGrid(len(g.bossProjectiles), func(i int) systems.Rect {
+		return g.bossProjectiles[i].Hitbox().Bounds()
+	})
+	hitbox := p.Hitbox()
+	near := g.nearby(hitbox.Bounds())
+	for k := len(near) - 1; k >= 0; k-- {
+		i := near[k]
+		if systems.Overlaps(g.bossProjectiles[i].Hitbox(), hitbox) {
+			isDead := g.damagePlayer(p)
 			g.bossNoDamage = false
 
 			g.bossProjectilePool.Put(g.bossProjectiles[i])
@@ -312,59 +347,68 @@ func (g *Game) checkBossProjectileCollisions() {
 			g.addScreenShake(config.ScreenShakeDuration)
 
 			if isDead {
-				g.handleGameOver()
-				return
+				return g.handleGameOver(p)
 			}
 			break
 		}
 	}
+	return false
 }
 
 func (g *Game) checkMinionPlayerCollision() {
+	for _, p := range g.ships() {
+		if g.checkMinionHit(p) {
+			return
+		}
+	}
+}
+
+func (g *Game) checkMinionHit(p *entities.Player) bool {
 	if g.boss == nil {
-		return
+		return false
 	}
 	minions := g.boss.GetMinions()
 	if minions == nil {
-		return
+		return false
 	}
 	for mIdx, minion := range minions {
-		if minion == nil || !minion.Collider().Intersects(g.player.Collider()) {
+		if minion == nil || !systems.Overlaps(minion.Hitbox(), p.Hitbox()) {
 			continue
 		}
 
-		isDead := g.player.TakeDamage()
+		isDead := g.damagePlayer(p)
 		g.bossNoDamage = false
 		g.createExplosion(minion.GetPosition(), config.MinionParticles)
 		g.boss.RemoveMinion(mIdx)
-		assets.PlayExplosionSound()
+		assets.PlayExplosionSound(minion.GetPosition().X)
 
 		g.addScreenShake(config.ScreenShakeDuration)
 
 		if isDead {
-			g.handleGameOver()
-			return
+			return g.handleGameOver(p)
 		}
 		break
 	}
+	return false
 }
 
-func (g *Game) defeatBoss() {
+// defeatBoss rewards the ship in slot, which landed the final hit.
+func (g *Game) defeatBoss(slot int) {
 	g.createExplosion(g.boss.GetPosition(), config.ParticleCount*config.ExplosionParticlesMul)
 
 	baseReward := config.BossReward
 
-	fightDuration := time.Since(g.boss.GetSpawnTime()).Seconds()
+	fightDuration := g.boss.FightDuration().Seconds()
 	if fightDuration < 30 {
 		timeBonus := int((30 - fightDuration) * 2)
 		baseReward += timeBonus
 		g.notification.Show(fmt.Sprintf("+%d TIME BONUS!", timeBonus), ui.NotificationLife)
 	}
 
-	g.score += baseReward
+	g.creditScore(slot, baseReward)
 	g.addScreenShake(config.ScreenShakeBossDefeat)
 	g.notification.Show(fmt.Sprintf("+%d BOSS DEFEATED!", baseReward), ui.NotificationSuperPower)
-	assets.PlayExplosionSound()
+	assets.PlayExplosionSound(g.boss.GetPosition().X)
 
 	numPowerUps := 1
 	if g.bossNoDamage {
@@ -374,7 +418,7 @@ func (g *Game) defeatBoss() {
 
 	for i := 0; i < numPowerUps; i++ {
 		p := g.powerUpPool.Get()
-		p.Reset()
+		p.Reset(g.rng)
 		g.powerUps = append(g.powerUps, p)
 	}
 
@@ -389,24 +433,32 @@ func (g *Game) defeatBoss() {
 	g.bossDefeated = true
 	g.bossCount++
 	g.bossCooldownTimer.Reset()
-	g.powerUpSpawnTimer = systems.NewTimer(config.PowerUpSpawnTime)
+	if g.isBossRush() {
+		g.wave = g.bossCount + 1
+	}
+	g.powerUpSpawnTimer = systems.NewTimer(g.powerUpInterval(g.waves.PowerUpsAt(g.wave)))
 
 	g.isPostBossInvincible = true
 	g.postBossInvincibilityTimer.Reset()
 	g.notification.Show("INVINCIBLE!", ui.NotificationShield)
 
+	if g.bossRushComplete() {
+		g.finishBossRush()
+		return
+	}
 	g.state = config.StatePlaying
 }
 
 func (g *Game) cleanBossObjects() {
-	validBossProjectiles := make([]*entities.BossProjectile, 0, len(g.bossProjectiles))
+	validBossProjectiles := g.bossProjectiles[:0]
 	for _, bp := range g.bossProjectiles {
-		if bp.IsOutOfScreen() {
+		if bp.IsOutOfScreen() || bp.IsExpired() {
 			g.bossProjectilePool.Put(bp)
 		} else {
 			validBossProjectiles = append(validBossProjectiles, bp)
 		}
 	}
+	clear(g.bossProjectiles[len(validBossProjectiles):])
 	g.bossProjectiles = validBossProjectiles
 
 	g.cleanPowerUps()
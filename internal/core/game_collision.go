@@ -13,19 +13,49 @@ func (g *Game) checkCollisions() bool {
 	return g.checkPlayerCollisions()
 }
 
+// fillGrid refills the collision grid with n things by index.
+func (g *Game) fillGrid(n int, bounds func(i int) systems.Rect) {
+	g.grid.Clear()
+	for i := 0; i < n; i++ {
+		g.grid.Insert(i, bounds(i))
+	}
+}
+
+// nearby lists, in ascending order, the indices in the grid that may touch
+// area. The slice is reused by the next call.
+func (g *Game) nearby(area systems.Rect) []int {
+	g.gridHits = g.grid.Query(area, g.gridHits[:0])
+	return g.gridHits
+}
+
+// resetFlags returns flags resized to n and all false, reusing its array.
+func resetFlags(flags []bool, n int) []bool {
+	if cap(flags) < n {
+		return make([]bool, n)
+	}
+	flags = flags[:n]
+	clear(flags)
+	return flags
+}
+
 func (g *Game) checkLaserMeteorCollisions() {
-	meteorsToRemove := make(map[int]bool)
-	lasersToRemove := make(map[int]bool)
+	meteorsToRemove := resetFlags(g.meteorFlags, len(g.meteors))
+	lasersToRemove := resetFlags(g.laserFlags, len(g.lasers))
+	g.meteorFlags, g.laserFlags = meteorsToRemove, lasersToRemove
 
+	g.fillGrid(len(g.lasers), func(i int) systems.Rect {
+		return g.lasers[i].Hitbox().Bounds()
+	})
 	for i := range g.meteors {
 		if meteorsToRemove[i] {
 			continue
 		}
-		for j := range g.lasers {
+		hitbox := g.meteors[i].Hitbox()
+		for _, j := range g.nearby(hitbox.Bounds()) {
 			if lasersToRemove[j] {
 				continue
 			}
-			if g.meteors[i].Collider().Intersects(g.lasers[j].Collider()) {
+			if systems.Overlaps(hitbox, g.lasers[j].Hitbox()) {
 				g.handleMeteorDestruction(i, j, meteorsToRemove, lasersToRemove)
 			}
 		}
@@ -35,40 +65,52 @@ func (g *Game) checkLaserMeteorCollisions() {
 	g.filterLasers(lasersToRemove)
 }
 
-func (g *Game) handleMeteorDestruction(meteorIdx, laserIdx int, meteorsToRemove, lasersToRemove map[int]bool) {
+func (g *Game) handleMeteorDestruction(meteorIdx, laserIdx int, meteorsToRemove, lasersToRemove []bool) {
 	meteorType := g.meteors[meteorIdx].GetType()
 	meteorPos := g.meteors[meteorIdx].GetPosition()
+	owner := g.lasers[laserIdx].Owner()
 
 	if meteorType == entities.MeteorExplosive {
-		g.handleExplosiveMeteor(meteorPos, meteorsToRemove)
+		g.handleExplosiveMeteor(owner, meteorPos, meteorsToRemove)
 	} else {
 		g.createExplosion(meteorPos, config.ParticleCount)
 	}
 
 	meteorsToRemove[meteorIdx] = true
-	g.meteorsDestroyed++
+	g.creditMeteor(owner)
 	if !g.laserBeamActive {
 		lasersToRemove[laserIdx] = true
 	}
 
-	g.combo++
-	g.comboTimer.Reset()
-	g.addScore(1)
-	assets.PlayExplosionSound()
+	combo, timer := g.comboFor(owner)
+	*combo++
+	timer.Reset()
+	g.addScore(owner, 1)
+	assets.PlayExplosionSound(meteorPos.X)
 }
 
 func (g *Game) checkPlayerCollisions() bool {
-	if g.checkMeteorPlayerCollision() {
-		return true
+	for _, p := range g.ships() {
+		if g.checkMeteorPlayerCollision(p) {
+			return true
+		}
+	}
+	for _, p := range g.ships() {
+		g.checkPowerUpPickup(p)
+		g.checkCoinPlayerCollision(p)
 	}
-	g.checkPowerUpPlayerCollision()
-	g.checkCoinPlayerCollision()
 	return false
 }
 
-func (g *Game) checkMeteorPlayerCollision() bool {
-	for i := len(g.meteors) - 1; i >= 0; i-- {
-		if !g.meteors[i].Collider().Intersects(g.player.Collider()) {
+func (g *Game) checkMeteorPlayerCollision(p *entities.Player) bool {
+	g.fillGrid(len(g.meteors), func(i int) systems.Rect {
+		return g.meteors[i].Hitbox().Bounds()
+	})
+	hitbox := p.Hitbox()
+	near := g.nearby(hitbox.Bounds())
+	for k := len(near) - 1; k >= 0; k-- {
+		i := near[k]
+		if !systems.Overlaps(g.meteors[i].Hitbox(), hitbox) {
 			continue
 		}
 
@@ -80,11 +122,11 @@ func (g *Game) checkMeteorPlayerCollision() bool {
 		meteorPos := g.meteors[i].GetPosition()
 
 		if meteorType == entities.MeteorIce {
-			g.handleIceMeteorCollision(i, meteorPos)
+			g.handleIceMeteorCollision(p, i, meteorPos)
 		} else if meteorType == entities.MeteorExplosive {
-			return g.handleExplosiveMeteorCollision(i, meteorPos)
+			return g.handleExplosiveMeteorCollision(p, i, meteorPos)
 		} else {
-			if g.handleNormalMeteorCollision(i, meteorPos) {
+			if g.handleNormalMeteorCollision(p, i, meteorPos) {
 				return true
 			}
 		}
@@ -93,8 +135,8 @@ func (g *Game) checkMeteorPlayerCollision() bool {
 	return false
 }
 
-func (g *Game) handleIceMeteorCollision(meteorIdx int, meteorPos systems.Vector) {
-	g.player.ApplySlow()
+func (g *Game) handleIceMeteorCollision(p *entities.Player, meteorIdx int, meteorPos systems.Vector) {
+	p.ApplySlow()
 	g.notification.Show("FROZEN!", ui.NotificationShield)
 	g.createExplosion(meteorPos, config.ParticleCount)
 
@@ -103,25 +145,25 @@ func (g *Game) handleIceMeteorCollision(meteorIdx int, meteorPos systems.Vector)
 	g.addScreenShake(config.ScreenShakeDuration)
 }
 
-func (g *Game) handleExplosiveMeteorCollision(meteorIdx int, meteorPos systems.Vector) bool {
-	isDead := g.player.TakeDamage()
+func (g *Game) handleExplosiveMeteorCollision(p *entities.Player, meteorIdx int, meteorPos systems.Vector) bool {
+	isDead := g.damagePlayer(p)
 
 	g.meteorPool.Put(g.meteors[meteorIdx])
 	g.meteors = append(g.meteors[:meteorIdx], g.meteors[meteorIdx+1:]...)
 
-	g.handleExplosiveMeteorDirect(meteorPos)
+	g.handleExplosiveMeteorDirect(p.Slot(), meteorPos)
 
-	return isDead && g.handleGameOver()
+	return isDead && g.handleGameOver(p)
 }
 
-func (g *Game) handleNormalMeteorCollision(meteorIdx int, meteorPos systems.Vector) bool {
-	isDead := g.player.TakeDamage()
+func (g *Game) handleNormalMeteorCollision(p *entities.Player, meteorIdx int, meteorPos systems.Vector) bool {
+	isDead := g.damagePlayer(p)
 	g.createExplosion(meteorPos, config.ParticleCount)
 
 	if isDead {
 		g.meteorPool.Put(g.meteors[meteorIdx])
 		g.meteors = append(g.meteors[:meteorIdx], g.meteors[meteorIdx+1:]...)
-		return g.handleGameOver()
+		return g.handleGameOver(p)
 	}
 
 	g.meteorPool.Put(g.meteors[meteorIdx])
@@ -130,35 +172,42 @@ func (g *Game) handleNormalMeteorCollision(meteorIdx int, meteorPos systems.Vect
 	return false
 }
 
-func (g *Game) checkPowerUpPlayerCollision() {
-	for i := len(g.powerUps) - 1; i >= 0; i-- {
-		if g.powerUps[i].Collider().Intersects(g.player.Collider()) {
+func (g *Game) checkPowerUpPickup(p *entities.Player) {
+	g.fillGrid(len(g.powerUps), func(i int) systems.Rect {
+		return g.powerUps[i].Hitbox().Bounds()
+	})
+	hitbox := p.Hitbox()
+	near := g.nearby(hitbox.Bounds())
+	for k := len(near) - 1; k >= 0; k-- {
+		i := near[k]
+		if systems.Overlaps(g.powerUps[i].Hitbox(), hitbox) {
 			powerType := g.powerUps[i].GetType()
 			g.powerUpPool.Put(g.powerUps[i])
 			g.powerUps = append(g.powerUps[:i], g.powerUps[i+1:]...)
 
-			g.handlePowerUpCollected(powerType)
-			assets.PlayPowerUpSound()
+			g.handlePowerUpCollected(p, powerType)
+			assets.PlayPowerUpSound(p.Collider().CenterX())
 			break
 		}
 	}
 }
 
-func (g *Game) checkCoinPlayerCollision() {
+func (g *Game) checkCoinPlayerCollision(p *entities.Player) {
 	for i := len(g.coins) - 1; i >= 0; i-- {
 		if g.coins[i].IsCollected() {
 			if g.coins[i].HasReachedTarget() {
-				coinValue := g.coins[i].GetValue()
+				coinValue := g.coinValue(g.coins[i].GetValue())
+				coinX, _, _, _ := g.coins[i].GetBounds()
 				g.progress.AddCoins(coinValue)
 				g.saveProgress()
 				g.coins = append(g.coins[:i], g.coins[i+1:]...)
-				assets.PlayCoinSound()
+				assets.PlayCoinSound(coinX)
 			}
 			continue
 		}
 
 		coinX, coinY, coinW, coinH := g.coins[i].GetBounds()
-		playerCollider := g.player.Collider()
+		playerCollider := p.Collider()
 
 		if coinX < playerCollider.X+playerCollider.Width && coinX+coinW > playerCollider.X &&
 			coinY < playerCollider.Y+playerCollider.Height && coinY+coinH > playerCollider.Y {
@@ -169,35 +218,39 @@ func (g *Game) checkCoinPlayerCollision() {
 	}
 }
 
-func (g *Game) filterMeteors(toRemove map[int]bool) {
-	newMeteors := make([]*entities.Meteor, 0, len(g.meteors))
+// filterMeteors and the clean functions compact their slice in place, so
+// the per-tick sweeps don't allocate.
+func (g *Game) filterMeteors(toRemove []bool) {
+	kept := g.meteors[:0]
 	for i, m := range g.meteors {
 		if toRemove[i] {
-			if entities.ShouldDropCoin() {
+			if entities.ShouldDropCoin(g.rng) {
 				coin := entities.NewCoinFromMeteor(m)
 				g.coins = append(g.coins, coin)
 			}
 			g.meteorPool.Put(m)
 		} else {
-			newMeteors = append(newMeteors, m)
+			kept = append(kept, m)
 		}
 	}
-	g.meteors = newMeteors
+	clear(g.meteors[len(kept):])
+	g.meteors = kept
 }
 
-func (g *Game) filterLasers(toRemove map[int]bool) {
-	newLasers := make([]*entities.Laser, 0, len(g.lasers))
+func (g *Game) filterLasers(toRemove []bool) {
+	kept := g.lasers[:0]
 	for i, l := range g.lasers {
 		if toRemove[i] {
 			g.laserPool.Put(l)
 		} else {
-			newLasers = append(newLasers, l)
+			kept = append(kept, l)
 		}
 	}
-	g.lasers = newLasers
+	clear(g.lasers[len(kept):])
+	g.lasers = kept
 }
 
-func (g *Game) handleExplosiveMeteor(explosionPos systems.Vector, meteorsToRemove map[int]bool) {
+func (g *Game) handleExplosiveMeteor(slot int, explosionPos systems.Vector, meteorsToRemove []bool) {
 	g.createExplosion(explosionPos, config.ParticleCount*3)
 	g.addScreenShake(15)
 
@@ -213,14 +266,14 @@ func (g *Game) handleExplosiveMeteor(explosionPos systems.Vector, meteorsToRemov
 
 		if distance < config.MeteorExplosiveDamageRadius*config.MeteorExplosiveDamageRadius {
 			g.createExplosion(meteorPos, 5)
-			g.addScore(1)
-			g.meteorsDestroyed++
+			g.addScore(slot, 1)
+			g.creditMeteor(slot)
 			meteorsToRemove[i] = true
 		}
 	}
 }
 
-func (g *Game) handleExplosiveMeteorDirect(explosionPos systems.Vector) {
+func (g *Game) handleExplosiveMeteorDirect(slot int, explosionPos systems.Vector) {
 
 	g.createExplosion(explosionPos, config.ParticleCount*3)
 	g.addScreenShake(15)
@@ -233,8 +286,8 @@ func (g *Game) handleExplosiveMeteorDirect(explosionPos systems.Vector) {
 
 		if distance < config.MeteorExplosiveDamageRadius*config.MeteorExplosiveDamageRadius {
 			g.createExplosion(meteorPos, 5)
-			g.addScore(1)
-			g.meteorsDestroyed++
+			g.addScore(slot, 1)
+			g.creditMeteor(slot)
 
 			g.meteorPool.Put(g.meteors[i])
 			g.meteors = append(g.meteors[:i], g.meteors[i+1:]...)
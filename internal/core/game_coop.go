@@ -0,0 +1,177 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/input"
+	"go-meteor/internal/systems"
+	"go-meteor/internal/ui"
+)
+
+// coopState is the second ship of a co-op run. Player one keeps using
+// g.player, g.combo and g.comboTimer; the team score stays in g.score.
+type coopState struct {
+	partner *entities.Player
+	// ships is both ships, player one first; ships() hands out the part
+	// still in play.
+	ships      [2]*entities.Player
+	controls   input.Controls
+	combo      int
+	comboTimer *systems.Timer
+	scores     [2]int
+	meteors    [2]int
+	knockedOut [2]bool
+}
+
+func (g *Game) isCoop() bool {
+	return g.mode == config.ModeCoop || g.mode == config.ModeCoopSplit
+}
+
+// sharedLives reports whether both ships draw on player one's lives.
+func (g *Game) sharedLives() bool {
+	return g.mode == config.ModeCoop
+}
+
+// setupCoop adds the partner ship and spreads both ships apart. It
+// clears co-op state outside of co-op modes.
+func (g *Game) setupCoop() {
+	g.coop = nil
+	if !g.headless {
+		input.LendPartnerPad(g.isCoop())
+	}
+	if !g.isCoop() {
+		return
+	}
+
+	partner := entities.NewPlayer(g)
+	partner.SetSlot(1)
+	partner.SetSkin(g.playerSkin())
+	partner.SetBaseLives(g.difficulty.Preset().Lives)
+
+	center := config.FieldWidth() / 2
+	g.player.SetCenterX(center - config.CoopShipSpread)
+	partner.SetCenterX(center + config.CoopShipSpread)
+
+	g.coop = &coopState{
+		partner:    partner,
+		ships:      [2]*entities.Player{g.player, partner},
+		comboTimer: systems.NewTimer(config.ComboTimeout),
+	}
+}
+
+// playerSkin is the skin the run was started with.
+func (g *Game) playerSkin() string {
+	if g.playback != nil {
+		return g.playback.Skin
+	}
+	if g.recorder != nil {
+		return g.recorder.Skin
+	}
+	if g.progress != nil {
+		return g.progress.EquippedSkin
+	}
+	return systems.DefaultSkin
+}
+
+// ships lists the ships still in play, player one first. It is called
+// several times a tick, so it slices stored arrays instead of allocating;
+// callers must not append to the result.
+func (g *Game) ships() []*entities.Player {
+	if g.coop == nil {
+		g.solo[0] = g.player
+		return g.solo[:]
+	}
+	c := g.coop
+	switch {
+	case c.knockedOut[0] && c.knockedOut[1]:
+		return c.ships[:0]
+	case c.knockedOut[0]:
+		return c.ships[1:]
+	case c.knockedOut[1]:
+		return c.ships[:1:1]
+	}
+	return c.ships[:]
+}
+
+func (g *Game) controlsFor(p *entities.Player) input.Controls {
+	if p.Slot() == 1 && g.coop != nil {
+		return g.coop.controls
+	}
+	return g.controls
+}
+
+func (g *Game) updateShips() {
+	for _, p := range g.ships() {
+		p.Update(g.controlsFor(p))
+	}
+}
+
+func (g *Game) updateShipTimers() {
+	for _, p := range g.ships() {
+		p.UpdateTimers()
+	}
+}
+
+// nearestShip is the ship closest to pos, used for boss and minion aim.
+// It reports false when no ship is in play, such as while both co-op
+// ships are knocked out.
+func (g *Game) nearestShip(pos systems.Vector) (*entities.Player, bool) {
+	var nearest *entities.Player
+	best := math.Inf(1)
+	for _, p := range g.ships() {
+		c := p.Collider()
+		dist := math.Hypot(c.X-pos.X, c.Y-pos.Y)
+		if dist < best {
+			nearest, best = p, dist
+		}
+	}
+	return nearest, nearest != nil
+}
+
+func (g *Game) comboFor(slot int) (*int, *systems.Timer) {
+	if slot == 1 && g.coop != nil {
+		return &g.coop.combo, g.coop.comboTimer
+	}
+	return &g.combo, g.comboTimer
+}
+
+// creditScore adds points to the team score and to the scoring ship.
+func (g *Game) creditScore(slot, points int) {
+	g.score += points
+	if g.coop != nil {
+		g.coop.scores[slot] += points
+	}
+}
+
+func (g *Game) creditMeteor(slot int) {
+	g.meteorsDestroyed++
+	if g.coop != nil {
+		g.coop.meteors[slot]++
+	}
+}
+
+// livesOwner is the ship whose lives p's hearts and hits count against.
+func (g *Game) livesOwner(p *entities.Player) *entities.Player {
+	if g.coop != nil && g.sharedLives() {
+		return g.player
+	}
+	return p
+}
+
+// knockOut takes a ship out of a co-op run with separate lives and
+// reports whether no ships are left.
+func (g *Game) knockOut(p *entities.Player) bool {
+	g.coop.knockedOut[p.Slot()] = true
+	if len(g.ships()) == 0 {
+		return true
+	}
+
+	collider := p.Collider()
+	g.createExplosion(systems.Vector{X: collider.X + collider.Width/2, Y: collider.Y + collider.Height/2}, config.PlayerDeathExplosionCount)
+	g.addScreenShake(config.ScreenShakeBossDefeat)
+	g.notification.Show(fmt.Sprintf("P%d DOWN!", p.Slot()+1), ui.NotificationWarning)
+	return false
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"strings"
+	"time"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/systems"
+	"go-meteor/internal/ui"
+)
+
+func (g *Game) isDaily() bool {
+	return g.mode == config.ModeDaily
+}
+
+// prepareDaily picks the challenge before the run is seeded. Every daily
+// run is played on Normal so all players face the same rules. Playback
+// takes the challenge from the replay rather than the clock.
+func (g *Game) prepareDaily() {
+	g.daily = nil
+	g.dailyScored = false
+
+	date := systems.DailyDate(time.Now())
+	if g.playback != nil {
+		if g.playback.Daily == "" {
+			return
+		}
+		date = g.playback.Daily
+	} else if !g.isDaily() {
+		return
+	}
+
+	daily := systems.NewDailyChallenge(date)
+	g.daily = &daily
+	if g.playback == nil {
+		g.difficulty = config.DifficultyNormal
+		g.queueSeed(daily.Seed)
+	}
+	g.notification.Show(dailyModifierText(g.daily), ui.NotificationSuperPower)
+}
+
+// claimDailyAttempt makes the run the scored attempt of the day if the
+// player has not used it yet. Restarts and later runs are practice.
+func (g *Game) claimDailyAttempt() {
+	if g.daily == nil || g.progress == nil {
+		return
+	}
+	if g.progress.ClaimDailyAttempt(g.daily.Date) {
+		g.dailyScored = true
+		g.saveProgress()
+	}
+}
+
+func dailyModifierText(daily *systems.DailyChallenge) string {
+	names := make([]string, 0, len(daily.Modifiers))
+	for _, m := range daily.Modifiers {
+		names = append(names, m.String())
+	}
+	return strings.Join(names, " + ")
+}
+
+// dailyMenuInfo describes today's challenge for the menu.
+func (g *Game) dailyMenuInfo() string {
+	date := systems.DailyDate(time.Now())
+	if g.menuDaily == nil || g.menuDaily.Date != date {
+		daily := systems.NewDailyChallenge(date)
+		g.menuDaily = &daily
+		g.menuDailyText = dailyModifierText(&daily)
+	}
+	if g.progress != nil && !g.progress.HasDailyAttempt(date) {
+		return g.menuDailyText + " (practice)"
+	}
+	return g.menuDailyText
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"go-meteor/internal/systems"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	hitboxShipColor  = color.RGBA{80, 255, 120, 255}
+	hitboxFoeColor   = color.RGBA{255, 80, 80, 255}
+	hitboxLaserColor = color.RGBA{80, 200, 255, 255}
+	hitboxItemColor  = color.RGBA{255, 220, 80, 255}
+)
+
+// handleDebugKey toggles the hitbox overlay on F3.
+func (g *Game) handleDebugKey() {
+	if !g.headless && inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		g.showHitboxes = !g.showHitboxes
+	}
+}
+
+// drawHitboxes outlines every shape collisions are tested against.
+func (g *Game) drawHitboxes(field *ebiten.Image) {
+	for _, p := range g.ships() {
+		drawShape(field, p.Hitbox(), hitboxShipColor)
+	}
+	for _, m := range g.meteors {
+		drawShape(field, m.Hitbox(), hitboxFoeColor)
+	}
+	for _, l := range g.lasers {
+		drawShape(field, l.Hitbox(), hitboxLaserColor)
+	}
+	for _, p := range g.powerUps {
+		drawShape(field, p.Hitbox(), hitboxItemColor)
+	}
+	for _, bp := range g.bossProjectiles {
+		drawShape(field, bp.Hitbox(), hitboxFoeColor)
+	}
+	if g.boss != nil {
+		drawShape(field, g.boss.Hitbox(), hitboxFoeColor)
+		for _, m := range g.boss.GetMinions() {
+			if m != nil {
+				drawShape(field, m.Hitbox(), hitboxFoeColor)
+			}
+		}
+	}
+}
+
+func drawShape(dst *ebiten.Image, s systems.Shape, clr color.Color) {
+	const width = 1.5
+	switch p := s.(type) {
+	case *systems.Capsule:
+		s = *p
+	case *systems.Polygon:
+		s = *p
+	}
+	switch s := s.(type) {
+	case systems.Circle:
+		vector.StrokeCircle(dst, float32(s.Center.X), float32(s.Center.Y), float32(s.Radius), width, clr, true)
+	case systems.Capsule:
+		vector.StrokeCircle(dst, float32(s.A.X), float32(s.A.Y), float32(s.Radius), width, clr, true)
+		vector.StrokeCircle(dst, float32(s.B.X), float32(s.B.Y), float32(s.Radius), width, clr, true)
+		dx, dy := s.B.X-s.A.X, s.B.Y-s.A.Y
+		if l := math.Hypot(dx, dy); l > 0 {
+			nx, ny := -dy/l*s.Radius, dx/l*s.Radius
+			vector.StrokeLine(dst, float32(s.A.X+nx), float32(s.A.Y+ny), float32(s.B.X+nx), float32(s.B.Y+ny), width, clr, true)
+			vector.StrokeLine(dst, float32(s.A.X-nx), float32(s.A.Y-ny), float32(s.B.X-nx), float32(s.B.Y-ny), width, clr, true)
+		}
+	case systems.Polygon:
+		for i, v := range s.Points {
+			w := s.Points[(i+1)%len(s.Points)]
+			vector.StrokeLine(dst, float32(v.X), float32(v.Y), float32(w.X), float32(w.Y), width, clr, true)
+		}
+	default:
+		b := s.Bounds()
+		vector.StrokeRect(dst, float32(b.X), float32(b.Y), float32(b.Width), float32(b.Height), width, clr, true)
+	}
+}
@@ -3,7 +3,13 @@
 
 package core
 
-import "time"
+import (
+	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
 
 func (g *Game) notifyWebLeaderboard(_ string, _ int) {
 }
@@ -16,8 +22,26 @@ func (g *Game) hasNameInputModal() bool {
 }
 
 func (g *Game) initNewGameSession() {
-	g.meteorsDestroyed = 0
-	g.powerUpsCollected = 0
-	g.gameStartTime = time.Now()
-	g.survivalTime = 0
+	g.beginSession()
+}
+
+func (g *Game) registerSuspendHandler() {
+	ebiten.SetWindowClosingHandled(true)
+}
+
+// handleFullscreenKey toggles fullscreen on F11 and keeps the choice.
+func (g *Game) handleFullscreenKey() {
+	if g.headless || !inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		return
+	}
+	opts := display.CurrentOptions()
+	opts.Fullscreen = !opts.Fullscreen
+	display.SetOptions(opts)
+	g.saveSettings()
+}
+
+// viewportOrientation is always landscape on desktop, where the window is
+// resized rather than turned.
+func viewportOrientation() config.Orientation {
+	return config.Landscape
 }
@@ -5,6 +5,9 @@ import (
 	"image/color"
 
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/input"
 	"go-meteor/internal/ui"
 	assets "go-meteor/src/pkg"
 
@@ -13,11 +16,34 @@ import (
 	"golang.org/x/image/font"
 )
 
+// Draw renders the playfield to the field canvas, menus to the fixed-size
+// UI canvas and the HUD to a view-sized layer, then fits them to the screen.
 func (g *Game) Draw(screen *ebiten.Image) {
+	if g.canvas == nil {
+		g.canvas = ebiten.NewImage(config.ScreenWidth, config.ScreenHeight)
+	}
+	g.canvas.Clear()
+	g.field = display.FieldImage(g.field)
+	g.hud = display.ViewImage(g.hud)
+
+	g.drawScene(g.field, g.canvas, g.hud)
+	display.Present(screen, g.field, g.canvas, g.hud)
+}
+
+// drawScene draws the world to field, menus and messages to screen and the
+// HUD to hud.
+func (g *Game) drawScene(field, screen, hud *ebiten.Image) {
+	defer g.padNotice.Draw(screen)
+
+	if g.state == config.StateReplay {
+		g.drawReplay(field, screen, hud)
+		return
+	}
+
 	offsetX := 0.0
 	offsetY := 0.0
 
-	if g.screenShake > 0 {
+	if g.screenShake > 0 && g.settingsMenu.ScreenShake() {
 		offsetX = (float64(g.screenShake%2)*2 - 1) * config.ScreenShakeIntensity
 		offsetY = (float64((g.screenShake+1)%2)*2 - 1) * config.ScreenShakeIntensity
 	}
@@ -26,21 +52,21 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	op.GeoM.Translate(offsetX, offsetY)
 
 	for _, s := range g.stars {
-		s.Draw(screen)
+		s.Draw(field)
 	}
 
 	switch g.state {
 	case config.StateMenu:
 		g.drawMenu(screen)
 	case config.StatePlaying:
-		g.drawPlaying(screen)
+		g.drawPlaying(field)
 	case config.StateBossAnnouncement:
-		g.drawPlaying(screen)
-		g.drawBossAnnouncement(screen)
+		g.drawPlaying(field)
+		g.drawBossAnnouncement(field, screen)
 	case config.StateBossFight:
-		g.drawBossFight(screen)
+		g.drawBossFight(field)
 	case config.StatePaused:
-		g.drawPlaying(screen)
+		g.drawPlaying(field)
 		g.pauseMenu.Draw(screen)
 	case config.StateGameOver:
 		g.drawGameOver(screen)
@@ -50,10 +76,24 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	case config.StateSettings:
 		g.drawMenu(screen)
 		g.settingsMenu.Draw(screen)
+		g.settingsMenu.DrawView(hud)
 	case config.StatePlayerDeath:
-		g.drawPlayerDeath(screen)
+		g.drawPlayerDeath(field, hud)
 	case config.StateWaitingNameInput:
-		g.drawPlaying(screen)
+		g.drawPlaying(field)
+	}
+
+	switch g.state {
+	case config.StatePlaying, config.StateBossAnnouncement, config.StateBossFight, config.StatePaused:
+		if g.showHitboxes {
+			g.drawHitboxes(field)
+		}
+		g.notification.Draw(screen)
+	}
+
+	switch g.state {
+	case config.StatePlaying, config.StateBossAnnouncement, config.StateBossFight, config.StateWaitingNameInput:
+		g.drawUI(hud)
 	}
 }
 
@@ -62,7 +102,7 @@ func (g *Game) drawMenu(screen *ebiten.Image) {
 }
 
 func (g *Game) drawPlaying(screen *ebiten.Image) {
-	g.player.Draw(screen)
+	g.drawShips(screen)
 
 	for _, m := range g.meteors {
 		m.Draw(screen)
@@ -81,40 +121,115 @@ func (g *Game) drawPlaying(screen *ebiten.Image) {
 	}
 
 	g.drawParticlesBatch(screen)
+}
 
-	g.drawUI(screen)
-	g.notification.Draw(screen)
+func (g *Game) drawShips(screen *ebiten.Image) {
+	for _, p := range g.ships() {
+		p.Draw(screen)
+	}
 }
 
+// drawUI draws the HUD, anchored to the corners of the view. In portrait
+// the hearts and power-up bars sit in the control strip between the touch
+// controls and the boss bar moves to the bottom of the field.
 func (g *Game) drawUI(screen *ebiten.Image) {
-	g.drawLives(screen)
+	if g.isTimeAttack() {
+		g.drawCountdown(screen)
+	} else {
+		g.drawLives(screen)
+	}
 	g.drawWaveAndCoins(screen)
 	g.drawScores(screen)
-	ui.DrawPauseIcon(screen, g.pauseIconX, g.pauseIconY)
+	iconX, iconY := g.pauseIconPos()
+	ui.DrawPauseIcon(screen, iconX, iconY)
+	g.drawBossBar(screen)
 	g.drawPowerUpBars(screen)
 	g.drawMobileControls(screen)
 }
 
+// drawLives draws player one's hearts, followed by the partner's tinted
+// hearts when co-op ships have their own lives.
 func (g *Game) drawLives(screen *ebiten.Image) {
-	lives := g.player.GetLives()
+	hearts := heartCount(g.player)
+	partner := g.coop != nil && !g.sharedLives()
+	if partner {
+		hearts += 1 + heartCount(g.coop.partner)
+	}
+
+	x, y := float64(config.HeartOffsetX), float64(config.HeartOffsetY)
+	if portrait() {
+		x = (display.Width() - float64(hearts*config.HeartSpacing)) / 2
+		y = display.StripTop() + config.HeartOffsetY
+	}
+
+	next := g.drawHearts(screen, g.player, x, y, 0)
+	if partner {
+		g.drawHearts(screen, g.coop.partner, x, y, next+1)
+	}
+}
+
+func heartCount(p *entities.Player) int {
+	return max(p.GetLives(), p.GetBaseLives())
+}
 
-	for i := 0; i < lives && i < config.InitialLives; i++ {
+// drawHearts draws p's lives from x, y starting at heart position first
+// and returns the position after the last one.
+func (g *Game) drawHearts(screen *ebiten.Image, p *entities.Player, x, y float64, first int) int {
+	lives := p.GetLives()
+	baseLives := p.GetBaseLives()
+
+	for i := 0; i < lives && i < baseLives; i++ {
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(float64(config.HeartOffsetX+i*config.HeartSpacing), config.HeartOffsetY)
+		op.GeoM.Translate(x+float64((first+i)*config.HeartSpacing), y)
+		tintPartner(op, p)
 		screen.DrawImage(assets.HeartUISprite, op)
 	}
 
-	extraLives := max(0, lives-config.InitialLives)
+	extraLives := max(0, lives-baseLives)
 	for i := 0; i < extraLives; i++ {
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(float64(config.HeartOffsetX+(config.InitialLives+i)*config.HeartSpacing), config.HeartOffsetY)
+		op.GeoM.Translate(x+float64((first+baseLives+i)*config.HeartSpacing), y)
+		tintPartner(op, p)
 		screen.DrawImage(assets.ExtraLifeUISprite, op)
 	}
+
+	return first + heartCount(p)
+}
+
+func tintPartner(op *ebiten.DrawImageOptions, p *entities.Player) {
+	if p.Slot() == 1 {
+		op.ColorScale.Scale(0.6, 1, 0.6, 1)
+	}
+}
+
+// waveLabel shows the wave in Classic and boss progress and time in Boss Rush.
+func (g *Game) waveLabel() string {
+	if g.isBossRush() {
+		boss := min(g.bossCount+1, config.BossRushBosses)
+		return fmt.Sprintf("Boss %d/%d  %s", boss, config.BossRushBosses, ui.FormatRunTime(g.runTime()))
+	}
+	return fmt.Sprintf("Wave: %d", g.wave)
+}
+
+// drawCountdown shows the Time Attack clock where the lives would be,
+// turning red for the last seconds.
+func (g *Game) drawCountdown(screen *ebiten.Image) {
+	remaining := g.timeAttackRemaining()
+	clr := color.Color(color.White)
+	if remaining <= config.TimeAttackWarningTime {
+		clr = color.RGBA{255, 80, 80, 255}
+	}
+	clock := ui.FormatRunTime(remaining)
+	if portrait() {
+		x := (int(display.Width()) - measureText(clock, assets.FontSmall)) / 2
+		drawText(screen, clock, assets.FontSmall, x, int(display.StripTop())+35, clr)
+		return
+	}
+	drawText(screen, clock, assets.FontSmall, 20, 35, clr)
 }
 
 func (g *Game) drawWaveAndCoins(screen *ebiten.Image) {
-	waveText := fmt.Sprintf("Wave: %d", g.wave)
-	drawText(screen, waveText, assets.FontSmall, 20, 65, color.White)
+	drawText(screen, g.waveLabel(), assets.FontSmall, 20, 65, color.White)
 
 	if g.progress != nil {
 		op := &ebiten.DrawImageOptions{}
@@ -128,30 +243,60 @@ func (g *Game) drawWaveAndCoins(screen *ebiten.Image) {
 
 	if g.combo > 1 {
 		comboText := fmt.Sprintf("%d COMBO", g.combo)
+		if g.coop != nil {
+			comboText = "P1 " + comboText
+		}
 		drawText(screen, comboText, assets.FontSmall, 20, 130, color.RGBA{255, 200, 0, 255})
 	}
+	if g.coop != nil && g.coop.combo > 1 {
+		comboText := fmt.Sprintf("P2 %d COMBO", g.coop.combo)
+		drawText(screen, comboText, assets.FontSmall, 20, 155, color.RGBA{150, 255, 150, 255})
+	}
 }
 
 func (g *Game) drawScores(screen *ebiten.Image) {
 	scoreText := fmt.Sprintf("Points: %d", g.score)
-	drawText(screen, scoreText, assets.FontSmall, 20, 570, color.White)
+	if g.coop != nil {
+		scoreText += fmt.Sprintf("  (P1 %d / P2 %d)", g.coop.scores[0], g.coop.scores[1])
+	}
+	x, y := display.Place(display.BottomLeft, 20, 570)
+	drawText(screen, scoreText, assets.FontSmall, int(x), int(y), color.White)
 
 	highScoreText := fmt.Sprintf("HIGH SCORE: %d", g.highScore)
-	highScoreX := config.ScreenWidth - measureText(highScoreText, assets.FontSmall) - 20
-	drawText(screen, highScoreText, assets.FontSmall, highScoreX, 570, color.White)
+	x, y = display.Place(display.BottomRight, float64(config.ScreenWidth-measureText(highScoreText, assets.FontSmall)-20), 570)
+	drawText(screen, highScoreText, assets.FontSmall, int(x), int(y), color.White)
+}
+
+// drawBossBar draws the boss's health across the top of the view, or just
+// above the scores in portrait, where the top is crowded.
+func (g *Game) drawBossBar(screen *ebiten.Image) {
+	if g.boss == nil {
+		return
+	}
+	y, width := float32(30), float32(600)
+	if portrait() {
+		y = float32(display.StripTop()) - 80
+		width = min(width, float32(display.Width())-40)
+	}
+	g.bossBar.Draw(screen, y, width, g.boss.GetHealth(), g.boss.GetMaxHealth(), g.boss.PhaseMarks())
 }
 
 func (g *Game) drawPowerUpBars(screen *ebiten.Image) {
 	barY := float32(config.PowerUpBarStartY)
+	if portrait() {
+		barY = float32(display.StripTop()) + 65
+	}
 
 	if g.superPowerActive {
 		ui.DrawPowerUpBarAt(screen, float32(g.superPowerTimer.Progress()), color.RGBA{255, 100, 255, 255}, barY)
 		barY += config.PowerUpBarSpacing
 	}
 
-	if g.player.HasShield() {
-		ui.DrawPowerUpBarAt(screen, float32(g.player.ShieldProgress()), color.RGBA{100, 200, 255, 255}, barY)
-		barY += config.PowerUpBarSpacing
+	for _, p := range g.ships() {
+		if p.HasShield() {
+			ui.DrawPowerUpBarAt(screen, float32(p.ShieldProgress()), color.RGBA{100, 200, 255, 255}, barY)
+			barY += config.PowerUpBarSpacing
+		}
 	}
 
 	if g.slowMotionActive {
@@ -181,28 +326,26 @@ func (g *Game) drawMobileControls(screen *ebiten.Image) {
 	}
 }
 
-func (g *Game) drawBossAnnouncement(screen *ebiten.Image) {
-	g.player.Draw(screen)
+func (g *Game) drawBossAnnouncement(field, screen *ebiten.Image) {
+	g.drawShips(field)
 
 	for _, m := range g.meteors {
-		m.Draw(screen)
+		m.Draw(field)
 	}
 
 	for _, b := range g.lasers {
-		b.Draw(screen)
+		b.Draw(field)
 	}
 
 	for _, p := range g.powerUps {
-		p.Draw(screen)
+		p.Draw(field)
 	}
 
 	for _, c := range g.coins {
-		c.Draw(screen)
+		c.Draw(field)
 	}
 
-	g.drawParticlesBatch(screen)
-
-	g.drawUI(screen)
+	g.drawParticlesBatch(field)
 
 	alpha := uint8(255)
 	if g.bossAnnouncementTimer < config.BossAnnouncementFade {
@@ -225,7 +368,7 @@ func (g *Game) drawBossAnnouncementText(screen *ebiten.Image, alpha uint8) {
 }
 
 func (g *Game) drawBossFight(screen *ebiten.Image) {
-	g.player.Draw(screen)
+	g.drawShips(screen)
 
 	if g.boss != nil {
 		g.boss.Draw(screen)
@@ -252,18 +395,15 @@ func (g *Game) drawBossFight(screen *ebiten.Image) {
 	}
 
 	g.drawParticlesBatch(screen)
-
-	g.drawUI(screen)
-
-	if g.boss != nil {
-		g.bossBar.Draw(screen, g.boss.GetHealth(), g.boss.GetMaxHealth())
-	}
-
-	g.notification.Draw(screen)
 }
 
 func (g *Game) drawGameOver(screen *ebiten.Image) {
 	youDiedText := "GAME OVER"
+	if g.bossRushCleared {
+		youDiedText = "BOSS RUSH CLEAR!"
+	} else if g.isTimeAttack() {
+		youDiedText = "TIME'S UP!"
+	}
 	youDiedX := (config.ScreenWidth - measureText(youDiedText, assets.FontUi)) / 2
 	drawText(screen, youDiedText, assets.FontUi, youDiedX, 150, color.White)
 
@@ -271,7 +411,7 @@ func (g *Game) drawGameOver(screen *ebiten.Image) {
 		g.statistics.Draw(screen, 220)
 	}
 
-	tryAgainText := "Press ENTER to play again"
+	tryAgainText := fmt.Sprintf("Press %s to play again", input.KeyLabel(input.ActionConfirm))
 	tryAgainX := (config.ScreenWidth - measureText(tryAgainText, assets.FontUi)) / 2
 	drawText(screen, tryAgainText, assets.FontUi, tryAgainX, 480, color.White)
 
@@ -286,7 +426,7 @@ func (g *Game) drawShopHint(screen *ebiten.Image) {
 	if g.isMobile {
 		g.drawShopIconButton(screen)
 	} else {
-		shopText := "Press S to open SHOP"
+		shopText := fmt.Sprintf("Press %s to open SHOP", input.KeyLabel(input.ActionShop))
 		shopX := (config.ScreenWidth - measureText(shopText, assets.FontSmall)) / 2
 		drawText(screen, shopText, assets.FontSmall, shopX, 535, color.RGBA{255, 215, 0, 255})
 	}
@@ -294,7 +434,7 @@ func (g *Game) drawShopHint(screen *ebiten.Image) {
 
 func (g *Game) drawShopIconButton(screen *ebiten.Image) {
 	const btnX, btnY, btnSize = 10.0, 10.0, 35.0
-	x, y := ebiten.CursorPosition()
+	x, y := display.CursorPosition()
 
 	iconOp := &ebiten.DrawImageOptions{}
 	if float64(x) >= btnX && float64(x) <= btnX+btnSize && float64(y) >= btnY && float64(y) <= btnY+btnSize {
@@ -344,7 +484,7 @@ func (g *Game) drawParticlesBatch(screen *ebiten.Image) {
 	screen.DrawTriangles(vertices, indices, emptyImage, nil)
 }
 
-func (g *Game) drawPlayerDeath(screen *ebiten.Image) {
+func (g *Game) drawPlayerDeath(screen, hud *ebiten.Image) {
 	// Draw game background (meteors, stars, particles)
 	for _, s := range g.stars {
 		s.Draw(screen)
@@ -370,10 +510,9 @@ func (g *Game) drawPlayerDeath(screen *ebiten.Image) {
 
 	// Draw UI
 	scoreText := fmt.Sprintf("Score: %d", g.score)
-	drawText(screen, scoreText, assets.FontSmall, 20, 30, color.White)
+	drawText(hud, scoreText, assets.FontSmall, 20, 30, color.White)
 
-	waveText := fmt.Sprintf("Wave: %d", g.wave)
-	drawText(screen, waveText, assets.FontSmall, 20, 65, color.White)
+	drawText(hud, g.waveLabel(), assets.FontSmall, 20, 65, color.White)
 }
 
 func drawText(screen *ebiten.Image, txt string, face font.Face, x, y int, clr color.Color) {
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"time"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/input"
+	"go-meteor/internal/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// padNoticeY keeps gamepad notices clear of the in-run notifications.
+	padNoticeY = config.ScreenHeight - 60
+
+	rumbleHitTime       = 200 * time.Millisecond
+	rumbleHitStrength   = 0.5
+	rumbleDeathTime     = 500 * time.Millisecond
+	rumbleDeathStrength = 1.0
+)
+
+// updateGamepads announces gamepads being plugged in or out. Losing one
+// mid-run pauses the game so the player isn't left without controls.
+func (g *Game) updateGamepads() {
+	g.padNotice.Update()
+	if g.headless {
+		return
+	}
+
+	if len(inpututil.AppendJustConnectedGamepadIDs(nil)) > 0 {
+		g.padNotice.Show("GAMEPAD CONNECTED", ui.NotificationLife)
+	}
+	for _, id := range g.gamepads {
+		if !inpututil.IsGamepadJustDisconnected(id) {
+			continue
+		}
+		g.padNotice.Show("GAMEPAD DISCONNECTED", ui.NotificationWarning)
+		if g.state == config.StatePlaying || g.state == config.StateBossFight {
+			g.pause(g.state)
+		}
+		break
+	}
+	g.gamepads = ebiten.AppendGamepadIDs(g.gamepads[:0])
+}
+
+// rumble shakes the gamepad of the ship that was hit, harder when it is
+// out of the run.
+func (g *Game) rumble(p *entities.Player, isDead bool) {
+	if g.headless || g.playback != nil {
+		return
+	}
+	if isDead {
+		input.Rumble(p.Slot() == 1, rumbleDeathTime, rumbleDeathStrength)
+		return
+	}
+	input.Rumble(p.Slot() == 1, rumbleHitTime, rumbleHitStrength)
+}
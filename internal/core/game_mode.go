@@ -0,0 +1,177 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/systems"
+	"go-meteor/internal/ui"
+	assets "go-meteor/src/pkg"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (g *Game) isBossRush() bool {
+	return g.mode == config.ModeBossRush
+}
+
+func (g *Game) isTimeAttack() bool {
+	return g.mode == config.ModeTimeAttack
+}
+
+// isRanked reports whether the run can enter a leaderboard. Boss Rush is
+// ranked by its stored best time instead, and only the day's first Daily
+// Challenge attempt counts.
+func (g *Game) isRanked() bool {
+	switch g.mode {
+	case config.ModeClassic, config.ModeTimeAttack:
+		return true
+	case config.ModeDaily:
+		return g.dailyScored
+	}
+	return false
+}
+
+func (g *Game) leaderboardCategory() systems.LeaderboardCategory {
+	category := systems.LeaderboardCategory{
+		Mode:       g.mode.Key(),
+		Difficulty: g.difficulty.Key(),
+	}
+	if g.daily != nil {
+		category.Challenge = g.daily.Date
+	}
+	return category
+}
+
+// bossScaling combines the difficulty preset with Boss Rush escalation,
+// where every boss defeated makes the next one tougher and faster.
+func (g *Game) bossScaling() entities.BossScaling {
+	scaling := entities.BossScaling{
+		Health:        g.difficulty.Preset().BossHealth,
+		ShootCooldown: 1,
+	}
+	if g.isBossRush() {
+		defeated := float64(g.bossCount)
+		scaling.Health *= 1 + defeated*config.BossRushHealthStep
+		scaling.ShootCooldown = max(config.BossRushMinCooldownScale, 1-defeated*config.BossRushCooldownStep)
+	}
+	if g.daily.Has(systems.ModifierDoubleBossHealth) {
+		scaling.Health *= config.DailyBossHealthFactor
+	}
+	return scaling
+}
+
+func (g *Game) bossCooldownTime() time.Duration {
+	if g.isBossRush() {
+		return config.BossRushIntermission
+	}
+	return config.BossCooldownTime
+}
+
+// runTime is the simulated time of the current run. Unlike gameStartTime it
+// leaves out pauses, so it can be compared between runs and replays.
+func (g *Game) runTime() time.Duration {
+	return time.Duration(g.runTicks) * time.Second / time.Duration(ebiten.TPS())
+}
+
+func (g *Game) bossRushComplete() bool {
+	return g.isBossRush() && g.bossCount >= config.BossRushBosses
+}
+
+func (g *Game) finishBossRush() {
+	g.bossRushCleared = true
+	g.pausedRunState = 0
+	g.clearSuspendedRun()
+	g.survivalTime = time.Since(g.gameStartTime)
+
+	best := g.storage.LoadBestTime(g.mode.Key(), g.difficulty.Key())
+	if best == 0 || g.runTime() < best {
+		g.storage.SaveBestTime(g.mode.Key(), g.difficulty.Key(), g.runTime())
+	}
+
+	g.statistics = g.newStatistics()
+	g.finishRecording()
+	g.state = config.StateGameOver
+}
+
+func (g *Game) timeAttackRemaining() time.Duration {
+	return max(0, config.TimeAttackDuration-g.runTime())
+}
+
+func (g *Game) timeAttackOver() bool {
+	return g.isTimeAttack() && g.runTime() >= config.TimeAttackDuration
+}
+
+func (g *Game) finishTimeAttack() {
+	g.pausedRunState = 0
+	g.clearSuspendedRun()
+	g.survivalTime = time.Since(g.gameStartTime)
+	g.statistics = g.newStatistics()
+	g.finishRecording()
+	assets.PlayGameOverSound(config.FieldWidth() / 2)
+	g.enterResults()
+}
+
+// enterResults shows the results screen, asking for a leaderboard name
+// first when the run made the top scores of its category.
+func (g *Game) enterResults() {
+	if g.isRanked() && g.leaderboard.IsTopScore(g.score, g.leaderboardCategory()) && !g.headless && g.hasNameInputModal() {
+		g.state = config.StateWaitingNameInput
+		g.showNameInputModal()
+		return
+	}
+	g.state = config.StateGameOver
+}
+
+// damagePlayer applies a hit to p and reports whether it ended the run,
+// rumbling the ship's gamepad when the hit lands.
+func (g *Game) damagePlayer(p *entities.Player) bool {
+	wasInvincible := p.IsInvincible()
+	isDead := g.applyDamage(p)
+	if isDead || (!wasInvincible && p.IsInvincible()) {
+		g.rumble(p, isDead)
+	}
+	return isDead
+}
+
+// applyDamage is damagePlayer without the feedback. In Time Attack a
+// landed hit costs points instead of a life; in co-op a ship either spends
+// the shared lives or is knocked out on its own.
+func (g *Game) applyDamage(p *entities.Player) bool {
+	if g.coop != nil {
+		if g.sharedLives() {
+			return p.TakeHit() && g.player.LoseLife()
+		}
+		return p.TakeDamage() && g.knockOut(p)
+	}
+	if !g.isTimeAttack() {
+		return p.TakeDamage()
+	}
+	if p.TakeHit() {
+		penalty := min(g.score, config.TimeAttackHitPenalty)
+		g.score -= penalty
+		g.timeAttackHits++
+		g.timeAttackPenalty += penalty
+		g.notification.Show(fmt.Sprintf("-%d", penalty), ui.NotificationWarning)
+	}
+	return false
+}
+
+func (g *Game) newStatistics() *ui.Statistics {
+	stats := ui.NewStatistics(g.meteorsDestroyed, g.powerUpsCollected, g.wave, g.score, g.survivalTime, g.difficulty)
+	if g.isBossRush() {
+		stats.SetBossRush(g.bossCount, config.BossRushBosses, g.runTime(), g.storage.LoadBestTime(g.mode.Key(), g.difficulty.Key()))
+	}
+	if g.isTimeAttack() {
+		stats.SetTimeAttack(g.timeAttackHits, g.timeAttackPenalty)
+	}
+	if g.daily != nil {
+		stats.SetDaily(g.daily.Date, g.dailyScored)
+	}
+	if g.coop != nil {
+		stats.SetCoop(g.coop.scores, g.coop.meteors)
+	}
+	return stats
+}
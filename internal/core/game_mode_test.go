@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"go-meteor/internal/config"
+)
+
+func TestBossRushBestTimePerDifficulty(t *testing.T) {
+	g := NewHeadless(1).Game()
+	g.mode = config.ModeBossRush
+
+	finish := func(d config.Difficulty, seconds int) {
+		g.difficulty = d
+		g.runTicks = seconds * 60
+		g.finishBossRush()
+	}
+	best := func(d config.Difficulty) time.Duration {
+		return g.storage.LoadBestTime(g.mode.Key(), d.Key())
+	}
+
+	finish(config.DifficultyNightmare, 300)
+	finish(config.DifficultyEasy, 120)
+	finish(config.DifficultyNightmare, 400)
+
+	if got := best(config.DifficultyNightmare); got != 300*time.Second {
+		t.Errorf("Nightmare best = %v, want 5m0s", got)
+	}
+	if got := best(config.DifficultyEasy); got != 120*time.Second {
+		t.Errorf("Easy best = %v, want 2m0s", got)
+	}
+	if got := best(config.DifficultyHard); got != 0 {
+		t.Errorf("Hard best = %v without a clear", got)
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"go-meteor/internal/config"
+)
+
+func portrait() bool {
+	return config.FieldOrientation() == config.Portrait
+}
+
+func orientationFor(portrait bool) config.Orientation {
+	if portrait {
+		return config.Portrait
+	}
+	return config.Landscape
+}
+
+// runOrientation picks the field for a new run: a replay keeps the one it
+// was recorded on and headless games stay landscape; otherwise it follows
+// the viewport.
+func (g *Game) runOrientation() config.Orientation {
+	switch {
+	case g.playback != nil:
+		return orientationFor(g.playback.Portrait)
+	case g.headless:
+		return config.Landscape
+	}
+	return viewportOrientation()
+}
+
+// setOrientation switches the field, dropping the stars spawned across the
+// old one.
+func (g *Game) setOrientation(o config.Orientation) {
+	if o == config.FieldOrientation() {
+		return
+	}
+	config.SetFieldOrientation(o)
+	g.stars = g.stars[:0]
+}
+
+// followViewport keeps the menu's field in step with the viewport, so a
+// phone turned between runs starts the next one the right way up.
+func (g *Game) followViewport() {
+	if g.state == config.StateMenu && !g.headless {
+		g.setOrientation(viewportOrientation())
+	}
+}
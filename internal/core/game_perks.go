@@ -0,0 +1,57 @@
+package core
+
+import (
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/systems"
+)
+
+// hasPerk reports whether the run owns an upgrade with the effect. Like
+// the power-up upgrades, perks are read from the levels snapshotted at run
+// start, so replays apply them too.
+func (g *Game) hasPerk(effect systems.UpgradeEffect) bool {
+	for _, u := range systems.Upgrades {
+		if u.Effect == effect && g.runUpgrades[u.ID] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// applyStartPerks gives the run its starting power-up.
+func (g *Game) applyStartPerks() {
+	if !g.hasPerk(systems.EffectStartBoost) {
+		return
+	}
+	g.superPowerActive = true
+	duration := config.SuperPowerTime + g.powerUpBonus(entities.PowerUpSuperShot.Name())
+	g.superPowerTimer = systems.NewTimer(duration)
+}
+
+// attractCoins pulls falling coins toward the nearest ship in range.
+func (g *Game) attractCoins() {
+	if !g.hasPerk(systems.EffectCoinMagnet) {
+		return
+	}
+	for _, c := range g.coins {
+		x, y, w, h := c.GetBounds()
+		center := systems.Vector{X: x + w/2, Y: y + h/2}
+		nearest, ok := g.nearestShip(center)
+		if !ok {
+			return
+		}
+		ship := nearest.Collider()
+		shipX, shipY := ship.X+ship.Width/2, ship.Y+ship.Height/2
+		dx, dy := shipX-center.X, shipY-center.Y
+		if dx*dx+dy*dy < config.CoinMagnetRadius*config.CoinMagnetRadius {
+			c.Attract(shipX, shipY, config.CoinMagnetSpeed)
+		}
+	}
+}
+
+func (g *Game) coinValue(base int) int {
+	if g.hasPerk(systems.EffectDoubleCoins) {
+		return base * 2
+	}
+	return base
+}
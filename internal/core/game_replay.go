@@ -0,0 +1,264 @@
+package core
+
+import (
+	"fmt"
+	"image/color"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/input"
+	"go-meteor/internal/systems"
+	assets "go-meteor/src/pkg"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+var replaySpeeds = []int{1, 2, 4}
+
+// replaySource feeds one player's recorded frames back to a game, one per
+// tick.
+type replaySource struct {
+	replay *systems.Replay
+	slot   int
+	tick   int
+}
+
+func (s *replaySource) Poll() input.Controls {
+	if s.Done() {
+		return input.Controls{}
+	}
+	c := input.ControlsFromBits(s.replay.Frame(s.tick, s.slot))
+	s.tick++
+	return c
+}
+
+func (s *replaySource) Done() bool {
+	return s.tick >= s.replay.Len()
+}
+
+// newReplayGame builds a game that re-simulates r. It saves to memory
+// only, so watching or verifying a replay never touches real progress.
+func newReplayGame(r *systems.Replay) (*Game, *replaySource) {
+	source := &replaySource{replay: r}
+	g := newGame(systems.NewMemoryStorage(), source)
+	g.headless = true
+	g.playback = r
+	if r.Players > 1 {
+		g.partnerSource = &replaySource{replay: r, slot: 1}
+	}
+	g.queueSeed(r.Seed)
+	g.beginSession()
+	g.state = config.StatePlaying
+	return g, source
+}
+
+// VerifyReplay re-simulates r and returns the score it reaches and whether
+// that matches the recorded final score.
+func VerifyReplay(r *systems.Replay) (int, bool) {
+	if r.Version != GameVersion || r.Waves != loadWaves().Checksum() {
+		return 0, false
+	}
+
+	// The replay's field is only borrowed for the check.
+	defer config.SetFieldOrientation(config.FieldOrientation())
+	g, source := newReplayGame(r)
+	for !source.Done() {
+		if err := g.Update(); err != nil {
+			return g.score, false
+		}
+	}
+	return g.score, g.score == r.FinalScore
+}
+
+func (g *Game) startRecording() {
+	if g.playback != nil {
+		g.recorder = nil
+		g.runUpgrades = copyUpgrades(g.playback.Upgrades)
+		g.difficulty = config.DifficultyFromKey(g.playback.Difficulty)
+		g.mode = config.GameModeFromKey(g.playback.Mode)
+		g.player.SetSkin(g.playback.Skin)
+		return
+	}
+
+	skin := systems.DefaultSkin
+	var upgrades map[string]int
+	if g.progress != nil {
+		skin = g.progress.EquippedSkin
+		upgrades = g.progress.Upgrades
+	}
+	g.runUpgrades = copyUpgrades(upgrades)
+	g.recorder = systems.NewReplay(GameVersion, g.seed, skin, g.runUpgrades)
+	g.recorder.Waves = g.waves.Checksum()
+	g.recorder.Difficulty = g.difficulty.Key()
+	g.recorder.Mode = g.mode.Key()
+	if g.daily != nil {
+		g.recorder.Daily = g.daily.Date
+	}
+	if g.isCoop() {
+		g.recorder.Players = 2
+	}
+	g.recorder.Portrait = portrait()
+}
+
+// recordTick stores the controls consumed by a run tick. Pause ticks are
+// kept so the log is complete; playback steps over them without pausing.
+func (g *Game) recordTick(state config.GameState) {
+	if g.recorder == nil {
+		return
+	}
+	if !isRunState(state) {
+		return
+	}
+	if g.coop != nil {
+		g.recorder.Record(g.controls.Bits(), g.coop.controls.Bits())
+	} else {
+		g.recorder.Record(g.controls.Bits())
+	}
+}
+
+func (g *Game) finishRecording() {
+	if g.recorder == nil {
+		return
+	}
+	g.recorder.Finish(g.score)
+	g.lastReplay = g.recorder
+	g.recorder = nil
+
+	if data, err := g.lastReplay.ToJSON(); err == nil {
+		g.storage.SaveReplay(data)
+	}
+}
+
+func (g *Game) loadReplay() {
+	data, err := g.storage.LoadReplay()
+	if err != nil || data == "" {
+		return
+	}
+	if r, err := systems.ReplayFromJSON(data); err == nil && r.Version == GameVersion && r.Waves == g.waves.Checksum() {
+		g.lastReplay = r
+	}
+}
+
+func (g *Game) pause(from config.GameState) {
+	if g.playback != nil {
+		return
+	}
+	g.stateBeforePause = from
+	g.pausedRunState = from
+	g.state = config.StatePaused
+	g.suspendRun()
+}
+
+func copyUpgrades(upgrades map[string]int) map[string]int {
+	copied := make(map[string]int, len(upgrades))
+	for k, v := range upgrades {
+		copied[k] = v
+	}
+	return copied
+}
+
+type replayViewer struct {
+	game     *Game
+	source   *replaySource
+	replay   *systems.Replay
+	speed    int
+	finished bool
+	// orientation is the menu's field, put back when the viewer closes.
+	orientation config.Orientation
+}
+
+func (g *Game) openReplay() {
+	if g.lastReplay == nil {
+		return
+	}
+	// The replay's field is only borrowed while it plays.
+	orientation := config.FieldOrientation()
+	game, source := newReplayGame(g.lastReplay)
+	game.settingsMenu.SetScreenShake(g.settingsMenu.ScreenShake())
+	g.viewer = &replayViewer{
+		game:        game,
+		source:      source,
+		replay:      g.lastReplay,
+		orientation: orientation,
+	}
+	g.state = config.StateReplay
+}
+
+func (g *Game) closeReplay() {
+	if g.viewer != nil {
+		g.setOrientation(g.viewer.orientation)
+	}
+	g.viewer = nil
+	g.menu.Reset()
+	g.state = config.StateMenu
+}
+
+func (g *Game) updateReplay() error {
+	v := g.viewer
+	if v == nil {
+		g.closeReplay()
+		return nil
+	}
+
+	if input.ActionJustPressed(input.ActionBack) {
+		g.closeReplay()
+		return nil
+	}
+
+	pointerPressed := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		len(inpututil.AppendJustPressedTouchIDs(nil)) > 0
+
+	if v.finished {
+		if pointerPressed || input.ActionJustPressed(input.ActionConfirm) {
+			g.closeReplay()
+		}
+		return nil
+	}
+
+	if pointerPressed || inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		v.speed = (v.speed + 1) % len(replaySpeeds)
+	}
+
+	for i := 0; i < replaySpeeds[v.speed] && !v.finished; i++ {
+		if err := v.step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// step advances the recorded run, then lets the death animation play out
+// once the frames run out.
+func (v *replayViewer) step() error {
+	if !v.source.Done() || v.game.state == config.StatePlayerDeath {
+		return v.game.Update()
+	}
+	v.finished = true
+	return nil
+}
+
+func (g *Game) drawReplay(field, screen, hud *ebiten.Image) {
+	v := g.viewer
+	if v == nil {
+		return
+	}
+	v.game.drawScene(field, screen, hud)
+
+	status := fmt.Sprintf("REPLAY x%d  -  F: speed  %s: exit", replaySpeeds[v.speed], input.KeyLabel(input.ActionBack))
+	statusColor := color.RGBA{255, 215, 0, 255}
+	if v.finished {
+		if v.game.score == v.replay.FinalScore {
+			status = fmt.Sprintf("Replay verified: %d", v.game.score)
+			statusColor = color.RGBA{100, 255, 100, 255}
+		} else {
+			status = fmt.Sprintf("Replay mismatch: %d (recorded %d)", v.game.score, v.replay.FinalScore)
+			statusColor = color.RGBA{255, 100, 100, 255}
+		}
+	}
+
+	bounds := text.BoundString(assets.FontSmall, status)
+	x := (config.ScreenWidth - bounds.Dx()) / 2
+	text.Draw(screen, status, assets.FontSmall, x, config.ScreenHeight-20, statusColor)
+}
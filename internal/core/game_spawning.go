@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
 	"go-meteor/internal/effects"
 	"go-meteor/internal/entities"
 	"go-meteor/internal/input"
@@ -42,58 +43,62 @@ func (g *Game) createExplosion(pos systems.Vector, count int) {
 	}
 
 	for i := 0; i < count; i++ {
-		g.particles = append(g.particles, effects.NewParticle(pos))
+		g.particles = append(g.particles, effects.NewParticle(g.fxRng, pos))
 	}
 }
 
-func (g *Game) handlePowerUpCollected(powerType entities.PowerUpType) {
+func (g *Game) handlePowerUpCollected(p *entities.Player, powerType entities.PowerUpType) {
 	g.powerUpsCollected++
+	bonus := g.powerUpBonus(powerType.Name())
 	switch powerType {
 	case entities.PowerUpSuperShot:
 		g.superPowerActive = true
-		duration := config.SuperPowerTime + (g.getUpgradeBonus("superpower") * time.Second)
+		duration := config.SuperPowerTime + bonus
 		g.superPowerTimer = systems.NewTimer(duration)
 		g.notification.Show("SUPER POWER!", ui.NotificationSuperPower)
 	case entities.PowerUpHeart:
-		g.player.Heal()
+		g.livesOwner(p).Heal()
 		g.notification.Show("+1 LIFE", ui.NotificationLife)
 	case entities.PowerUpShield:
-		duration := config.ShieldTime + (g.getUpgradeBonus("shield") * time.Second)
-		g.player.ActivateShieldWithDuration(duration)
+		duration := config.ShieldTime + bonus
+		p.ActivateShieldWithDuration(duration)
 		g.notification.Show("SHIELD ACTIVE", ui.NotificationShield)
 	case entities.PowerUpSlowMotion:
 		g.slowMotionActive = true
-		duration := config.SlowMotionTime + (g.getUpgradeBonus("slowmotion") * time.Second)
+		duration := config.SlowMotionTime + bonus
 		g.slowMotionTimer = systems.NewTimer(duration)
 		g.notification.Show("SLOW MOTION!", ui.NotificationSuperPower)
 	case entities.PowerUpLaser:
 		g.laserBeamActive = true
-		duration := config.LaserBeamTime + (g.getUpgradeBonus("laser") * time.Second)
+		duration := config.LaserBeamTime + bonus
 		g.laserBeamTimer = systems.NewTimer(duration)
 		g.notification.Show("LASER BEAM!", ui.NotificationSuperPower)
 	case entities.PowerUpNuke:
-		g.activateNuke()
+		g.activateNuke(p.Slot())
 		g.notification.Show("NUKE ACTIVATED!", ui.NotificationSuperPower)
 	case entities.PowerUpExtraLife:
-		g.player.GainExtraLife()
+		g.livesOwner(p).GainExtraLife()
 		g.notification.Show("EXTRA LIFE!", ui.NotificationLife)
 	case entities.PowerUpMultiplier:
 		g.multiplierActive = true
-		duration := config.MultiplierTime + (g.getUpgradeBonus("multiplier") * time.Second)
+		duration := config.MultiplierTime + bonus
 		g.multiplierTimer = systems.NewTimer(duration)
 		g.notification.Show("SCORE x2!", ui.NotificationSuperPower)
 	}
 }
 
-func (g *Game) getUpgradeBonus(powerType string) time.Duration {
-	if g.progress == nil {
+// powerUpBonus is the extra duration upgrades give a power-up. It reads
+// the levels snapshotted when the run started, so shop purchases made
+// while paused apply from the next run.
+func (g *Game) powerUpBonus(powerUp string) time.Duration {
+	upgrade, ok := systems.UpgradeForPowerUp(powerUp)
+	if !ok {
 		return 0
 	}
-	level := g.progress.GetUpgradeLevel(powerType)
-	return time.Duration(level * 2)
+	return upgrade.Bonus(g.runUpgrades[upgrade.ID])
 }
 
-func (g *Game) activateNuke() {
+func (g *Game) activateNuke(slot int) {
 	meteorsDestroyed := len(g.meteors)
 
 	for _, m := range g.meteors {
@@ -103,11 +108,11 @@ func (g *Game) activateNuke() {
 
 	g.meteors = g.meteors[:0]
 
-	g.addScore(meteorsDestroyed * 2)
+	g.addScore(slot, meteorsDestroyed*2)
 	g.addScreenShake(20)
 	g.nukeActive = true
 	g.nukeTimer.Reset()
-	assets.PlayExplosionSound()
+	assets.PlayExplosionSound(config.FieldWidth() / 2)
 }
 
 func (g *Game) cleanObjects() {
@@ -119,7 +124,7 @@ func (g *Game) cleanObjects() {
 }
 
 func (g *Game) cleanMeteors() {
-	validMeteors := make([]*entities.Meteor, 0, len(g.meteors))
+	validMeteors := g.meteors[:0]
 	for _, m := range g.meteors {
 		if m.IsOutOfScreen() {
 			g.meteorPool.Put(m)
@@ -127,11 +132,12 @@ func (g *Game) cleanMeteors() {
 			validMeteors = append(validMeteors, m)
 		}
 	}
+	clear(g.meteors[len(validMeteors):])
 	g.meteors = validMeteors
 }
 
 func (g *Game) cleanLasers() {
-	validLasers := make([]*entities.Laser, 0, len(g.lasers))
+	validLasers := g.lasers[:0]
 	for _, l := range g.lasers {
 		if l.IsOutOfScreen() {
 			g.laserPool.Put(l)
@@ -139,11 +145,12 @@ func (g *Game) cleanLasers() {
 			validLasers = append(validLasers, l)
 		}
 	}
+	clear(g.lasers[len(validLasers):])
 	g.lasers = validLasers
 }
 
 func (g *Game) cleanPowerUps() {
-	validPowerUps := make([]*entities.PowerUp, 0, len(g.powerUps))
+	validPowerUps := g.powerUps[:0]
 	for _, p := range g.powerUps {
 		if p.IsOutOfScreen() {
 			g.powerUpPool.Put(p)
@@ -151,36 +158,40 @@ func (g *Game) cleanPowerUps() {
 			validPowerUps = append(validPowerUps, p)
 		}
 	}
+	clear(g.powerUps[len(validPowerUps):])
 	g.powerUps = validPowerUps
 }
 
 func (g *Game) cleanParticles() {
-	validParticles := make([]*effects.Particle, 0, len(g.particles))
+	validParticles := g.particles[:0]
 	for _, p := range g.particles {
 		if !p.IsDead() {
 			validParticles = append(validParticles, p)
 		}
 	}
+	clear(g.particles[len(validParticles):])
 	g.particles = validParticles
 }
 
 func (g *Game) cleanCoins() {
-	validCoins := make([]*entities.Coin, 0, len(g.coins))
+	validCoins := g.coins[:0]
 	for _, c := range g.coins {
 		if !c.IsOffScreen() {
 			validCoins = append(validCoins, c)
 		}
 	}
+	clear(g.coins[len(validCoins):])
 	g.coins = validCoins
 }
 
 func (g *Game) cleanStars() {
-	validStars := make([]*entities.Star, 0, len(g.stars))
+	validStars := g.stars[:0]
 	for _, s := range g.stars {
 		if !s.IsOutOfScreen() {
 			validStars = append(validStars, s)
 		}
 	}
+	clear(g.stars[len(validStars):])
 	g.stars = validStars
 }
 
@@ -234,9 +245,12 @@ func (g *Game) Reset() {
 	g.bossProjectiles = g.bossProjectiles[:0]
 	g.meteoSpawnTimer.Reset()
 	g.starSpawnTimer.Reset()
-	g.powerUpSpawnTimer.Reset()
 	g.comboTimer.Reset()
 	g.bossCooldownTimer.Reset()
+	g.powerUpSpawnTimer = systems.NewTimer(g.powerUpInterval(g.waves.PowerUpsAt(1)))
+	g.postBossInvincibilityTimer.Reset()
+	g.isPostBossInvincible = false
+	g.pausedRunState = 0
 
 	g.saveHighScore()
 
@@ -262,11 +276,13 @@ func (g *Game) Reset() {
 	g.initNewGameSession()
 }
 
-func (g *Game) handleGameOver() bool {
-	collider := g.player.Collider()
+func (g *Game) handleGameOver(p *entities.Player) bool {
+	collider := p.Collider()
 	g.playerDeathExplosionX = collider.X + collider.Width/2
 	g.playerDeathExplosionY = collider.Y + collider.Height/2
 	g.playerDeathTimer = 0
+	g.pausedRunState = 0
+	g.clearSuspendedRun()
 
 	// Create initial large explosion
 	g.createExplosion(
@@ -275,15 +291,17 @@ func (g *Game) handleGameOver() bool {
 	)
 	g.screenShake = config.ScreenShakeBossDefeat
 
-	assets.PlayExplosionSound()
-	assets.PlayGameOverSound()
+	assets.PlayExplosionSound(g.playerDeathExplosionX)
+	assets.PlayGameOverSound(g.playerDeathExplosionX)
 
 	g.state = config.StatePlayerDeath
 	return true
 }
 
-func (g *Game) addScore(basePoints int) {
-	points := basePoints + int(float64(g.combo)*config.ComboMultiplier)
+// addScore credits points to the ship in slot, boosted by its combo.
+func (g *Game) addScore(slot, basePoints int) {
+	combo, _ := g.comboFor(slot)
+	points := basePoints + int(float64(*combo)*config.ComboMultiplier)
 	if points < 1 {
 		points = 1
 	}
@@ -292,9 +310,9 @@ func (g *Game) addScore(basePoints int) {
 		points = int(float64(points) * config.MultiplierBonus)
 	}
 
-	g.score += points
+	g.creditScore(slot, points)
 
-	if g.score >= g.wave*config.WaveScoreThreshold {
+	if !g.isBossRush() && g.score >= g.wave*config.WaveScoreThreshold {
 		g.wave++
 	}
 }
@@ -307,8 +325,9 @@ func (g *Game) loadHighScore() {
 	g.highScore = g.storage.LoadHighScore()
 }
 
+// saveHighScore only counts Classic runs; other modes score differently.
 func (g *Game) saveHighScore() {
-	if g.score > g.highScore {
+	if g.mode == config.ModeClassic && g.score > g.highScore {
 		g.highScore = g.score
 		g.storage.SaveHighScore(g.highScore)
 	}
@@ -316,8 +335,18 @@ func (g *Game) saveHighScore() {
 
 func (g *Game) loadLeaderboard() {
 	data, err := g.storage.LoadLeaderboard()
-	if err == nil {
-		g.leaderboard.FromJSON(data)
+	if err != nil || g.leaderboard.FromJSON(data) != nil {
+		return
+	}
+	// Scores saved before difficulties and modes existed were all Classic
+	// runs played on Normal.
+	for i := range g.leaderboard.Entries {
+		if g.leaderboard.Entries[i].Difficulty == "" {
+			g.leaderboard.Entries[i].Difficulty = config.DifficultyNormal.Key()
+		}
+		if g.leaderboard.Entries[i].Mode == "" {
+			g.leaderboard.Entries[i].Mode = config.ModeClassic.Key()
+		}
 	}
 }
 
@@ -336,6 +365,47 @@ func (g *Game) saveProgress() {
 	}
 }
 
+// loadSettings applies the saved options before the first frame. Headless
+// games never do: the options are process-wide and belong to the player.
+func (g *Game) loadSettings() {
+	if g.headless {
+		return
+	}
+	settings, err := g.storage.LoadSettings()
+	if err != nil {
+		settings = systems.NewSettings()
+	}
+	assets.SetMasterVolume(settings.MasterVolume)
+	assets.SetSFXVolume(settings.SFXVolume)
+	assets.SetSFXEnabled(settings.SFXEnabled)
+	g.settingsMenu.SetScreenShake(settings.ScreenShake)
+	input.SetBindings(input.BindingsFromNames(settings.Bindings))
+	if settings.Touch != nil {
+		input.SetTouchLayout(input.TouchLayout(*settings.Touch))
+	}
+	if settings.Display != nil {
+		display.SetOptions(display.Options(*settings.Display))
+	}
+}
+
+func (g *Game) saveSettings() {
+	if g.headless {
+		return
+	}
+	bindings := input.CurrentBindings()
+	touch := systems.TouchSettings(input.CurrentTouchLayout())
+	view := systems.DisplaySettings(display.CurrentOptions())
+	g.storage.SaveSettings(&systems.Settings{
+		MasterVolume: assets.GetMasterVolume(),
+		SFXVolume:    assets.GetSFXVolume(),
+		SFXEnabled:   assets.IsSFXEnabled(),
+		ScreenShake:  g.settingsMenu.ScreenShake(),
+		Bindings:     bindings.Names(),
+		Touch:        &touch,
+		Display:      &view,
+	})
+}
+
 func (g *Game) initMobileControls() {
 	if g.joystick == nil {
 		g.joystick = input.NewJoystick(config.JoystickOffsetX, float64(config.ScreenHeight-config.JoystickOffsetY), config.JoystickRadius)
@@ -343,24 +413,41 @@ func (g *Game) initMobileControls() {
 	if g.shootButton == nil {
 		g.shootButton = input.NewShootButton(float64(config.ScreenWidth-config.ShootButtonOffsetX), float64(config.ScreenHeight-config.ShootButtonOffsetY), config.ShootButtonRadius)
 	}
+	g.applyTouchLayout()
+}
+
+// applyTouchLayout places the touch controls as set in the layout editor.
+func (g *Game) applyTouchLayout() {
+	if g.joystick == nil || g.shootButton == nil {
+		return
+	}
+	layout := input.CurrentTouchLayout()
+	g.joystick.ApplyLayout(layout)
+	g.shootButton.ApplyLayout(layout)
 }
 
 func (g *Game) shouldPause() bool {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyP) {
+	if g.controls.Pause || (g.coop != nil && g.coop.controls.Pause) {
 		return true
 	}
 
+	if g.headless {
+		return false
+	}
+
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
+		x, y := display.ViewCursorPosition()
 		if g.isPauseIconClicked(x, y) {
+			g.controls.Pause = true
 			return true
 		}
 	}
 
 	touchIDs := ebiten.AppendTouchIDs(nil)
 	for _, id := range touchIDs {
-		x, y := ebiten.TouchPosition(id)
+		x, y := display.ViewTouchPosition(id)
 		if g.isPauseIconClicked(x, y) {
+			g.controls.Pause = true
 			return true
 		}
 	}
@@ -370,40 +457,35 @@ func (g *Game) shouldPause() bool {
 
 func (g *Game) isPauseIconClicked(x, y int) bool {
 	const iconSize = 30
-	return x >= g.pauseIconX && x <= g.pauseIconX+iconSize &&
-		y >= g.pauseIconY && y <= g.pauseIconY+iconSize
+	iconX, iconY := g.pauseIconPos()
+	return x >= iconX && x <= iconX+iconSize &&
+		y >= iconY && y <= iconY+iconSize
+}
+
+// pauseIconPos is where the pause icon sits in the view, in its top-right
+// corner.
+func (g *Game) pauseIconPos() (int, int) {
+	x, y := display.Place(display.TopRight, float64(g.pauseIconX), float64(g.pauseIconY))
+	return int(x), int(y)
 }
 
 func (g *Game) handleMobileControls(touchIDs []ebiten.TouchID) {
-	if !g.isMobile || g.joystick == nil || g.shootButton == nil {
+	if g.headless || !g.isMobile || g.joystick == nil || g.shootButton == nil {
 		return
 	}
 
+	// The view changes shape with the window, so the controls are placed
+	// afresh each tick.
+	g.applyTouchLayout()
 	g.joystick.Update(touchIDs)
 
 	// Shoot continuously while button is pressed (like desktop)
-	if g.shootButton.Update(touchIDs) || g.shootButton.IsActive() {
-		g.player.Shoot()
-	}
-
-	dx, dy := g.joystick.GetDirection()
-	if !g.joystick.IsPressed() {
-		return
+	g.shootButton.Update(touchIDs)
+	if g.shootButton.IsFiring() {
+		g.controls.Shoot = true
 	}
 
-	const threshold = 0.3
-	if dx < -threshold {
-		g.player.MoveLeft()
-	}
-	if dx > threshold {
-		g.player.MoveRight()
-	}
-	if dy < -threshold {
-		g.player.MoveUp()
-	}
-	if dy > threshold {
-		g.player.MoveDown()
-	}
+	g.controls = g.controls.Merge(g.joystick.Controls())
 }
 
 // Helper functions
@@ -413,7 +495,7 @@ func (g *Game) AddLaser(l *entities.Laser) {
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return config.ScreenWidth, config.ScreenHeight
+	return display.Layout(outsideWidth, outsideHeight)
 }
 
 func (g *Game) GetSuperPowerActive() bool {
@@ -424,7 +506,8 @@ func (g *Game) GetLaserBeamActive() bool {
 	return g.laserBeamActive
 }
 
-func (g *Game) ResetCombo() {
-	g.combo = 0
-	g.comboTimer.Reset()
+func (g *Game) ResetCombo(slot int) {
+	combo, timer := g.comboFor(slot)
+	*combo = 0
+	timer.Reset()
 }
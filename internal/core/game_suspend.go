@@ -0,0 +1,343 @@
+package core
+
+import (
+	"encoding/json"
+	"time"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/systems"
+)
+
+// runSnapshot is a suspended run. Together with the draw count of the run's
+// random source it restores the simulation exactly, so the replay recorded
+// so far keeps going after a resume.
+type runSnapshot struct {
+	Version    string           `json:"version"`
+	Waves      string           `json:"waves"`
+	Seed       int64            `json:"seed"`
+	Draws      uint64           `json:"draws"`
+	State      config.GameState `json:"state"`
+	Skin       string           `json:"skin"`
+	Upgrades   map[string]int   `json:"upgrades"`
+	Difficulty string           `json:"difficulty"`
+	Mode       string           `json:"mode"`
+	Portrait   bool             `json:"portrait,omitempty"`
+	RunTicks   int              `json:"runTicks"`
+	Replay     string           `json:"replay,omitempty"`
+
+	Score             int           `json:"score"`
+	Combo             int           `json:"combo"`
+	Wave              int           `json:"wave"`
+	MeteorsDestroyed  int           `json:"meteorsDestroyed"`
+	PowerUpsCollected int           `json:"powerUpsCollected"`
+	Elapsed           time.Duration `json:"elapsed"`
+	TimeAttackHits    int           `json:"timeAttackHits,omitempty"`
+	TimeAttackPenalty int           `json:"timeAttackPenalty,omitempty"`
+	Daily             string        `json:"daily,omitempty"`
+	DailyScored       bool          `json:"dailyScored,omitempty"`
+
+	Player          entities.PlayerState           `json:"player"`
+	Coop            *coopSnapshot                  `json:"coop,omitempty"`
+	Meteors         []entities.MeteorState         `json:"meteors"`
+	Lasers          []entities.LaserState          `json:"lasers"`
+	PowerUps        []entities.PowerUpState        `json:"powerUps"`
+	Coins           []entities.CoinState           `json:"coins"`
+	BossProjectiles []entities.BossProjectileState `json:"bossProjectiles"`
+	Boss            *entities.BossState            `json:"boss,omitempty"`
+
+	BossWarningShown      bool `json:"bossWarningShown"`
+	BossAnnouncementTimer int  `json:"bossAnnouncementTimer"`
+	BossDefeated          bool `json:"bossDefeated"`
+	BossCount             int  `json:"bossCount"`
+	BossNoDamage          bool `json:"bossNoDamage"`
+	PostBossInvincible    bool `json:"postBossInvincible"`
+
+	SuperPowerActive bool `json:"superPowerActive"`
+	SlowMotionActive bool `json:"slowMotionActive"`
+	LaserBeamActive  bool `json:"laserBeamActive"`
+	NukeActive       bool `json:"nukeActive"`
+	MultiplierActive bool `json:"multiplierActive"`
+
+	Timers map[string]systems.TimerState `json:"timers"`
+}
+
+type coopSnapshot struct {
+	Partner    entities.PlayerState `json:"partner"`
+	Combo      int                  `json:"combo"`
+	Scores     [2]int               `json:"scores"`
+	Meteors    [2]int               `json:"meteors"`
+	KnockedOut [2]bool              `json:"knockedOut"`
+}
+
+// runTimers names every timer that affects the simulation. Some of them are
+// replaced mid-run with new durations, so the fields themselves are saved.
+func (g *Game) runTimers() map[string]**systems.Timer {
+	timers := map[string]**systems.Timer{
+		"meteorSpawn":           &g.meteoSpawnTimer,
+		"powerUpSpawn":          &g.powerUpSpawnTimer,
+		"superPower":            &g.superPowerTimer,
+		"combo":                 &g.comboTimer,
+		"slowMotion":            &g.slowMotionTimer,
+		"laserBeam":             &g.laserBeamTimer,
+		"nuke":                  &g.nukeTimer,
+		"multiplier":            &g.multiplierTimer,
+		"bossCooldown":          &g.bossCooldownTimer,
+		"postBossInvincibility": &g.postBossInvincibilityTimer,
+	}
+	if g.coop != nil {
+		timers["coopCombo"] = &g.coop.comboTimer
+	}
+	return timers
+}
+
+func (g *Game) snapshotRun(state config.GameState) *runSnapshot {
+	s := &runSnapshot{
+		Version:               GameVersion,
+		Waves:                 g.waves.Checksum(),
+		Seed:                  g.seed,
+		Draws:                 g.rngSource.draws,
+		State:                 state,
+		Skin:                  systems.DefaultSkin,
+		Upgrades:              copyUpgrades(g.runUpgrades),
+		Difficulty:            g.difficulty.Key(),
+		Mode:                  g.mode.Key(),
+		Portrait:              portrait(),
+		RunTicks:              g.runTicks,
+		Score:                 g.score,
+		Combo:                 g.combo,
+		Wave:                  g.wave,
+		MeteorsDestroyed:      g.meteorsDestroyed,
+		PowerUpsCollected:     g.powerUpsCollected,
+		Elapsed:               time.Since(g.gameStartTime),
+		TimeAttackHits:        g.timeAttackHits,
+		TimeAttackPenalty:     g.timeAttackPenalty,
+		DailyScored:           g.dailyScored,
+		Player:                g.player.State(),
+		BossWarningShown:      g.bossWarningShown,
+		BossAnnouncementTimer: g.bossAnnouncementTimer,
+		BossDefeated:          g.bossDefeated,
+		BossCount:             g.bossCount,
+		BossNoDamage:          g.bossNoDamage,
+		PostBossInvincible:    g.isPostBossInvincible,
+		SuperPowerActive:      g.superPowerActive,
+		SlowMotionActive:      g.slowMotionActive,
+		LaserBeamActive:       g.laserBeamActive,
+		NukeActive:            g.nukeActive,
+		MultiplierActive:      g.multiplierActive,
+		Timers:                make(map[string]systems.TimerState),
+	}
+
+	if g.daily != nil {
+		s.Daily = g.daily.Date
+	}
+	if g.coop != nil {
+		s.Coop = &coopSnapshot{
+			Partner:    g.coop.partner.State(),
+			Combo:      g.coop.combo,
+			Scores:     g.coop.scores,
+			Meteors:    g.coop.meteors,
+			KnockedOut: g.coop.knockedOut,
+		}
+	}
+	if g.recorder != nil {
+		s.Skin = g.recorder.Skin
+		if data, err := g.recorder.ToJSON(); err == nil {
+			s.Replay = data
+		}
+	}
+
+	for _, m := range g.meteors {
+		s.Meteors = append(s.Meteors, m.State())
+	}
+	for _, l := range g.lasers {
+		s.Lasers = append(s.Lasers, l.State())
+	}
+	for _, p := range g.powerUps {
+		s.PowerUps = append(s.PowerUps, p.State())
+	}
+	for _, c := range g.coins {
+		s.Coins = append(s.Coins, c.State())
+	}
+	for _, bp := range g.bossProjectiles {
+		s.BossProjectiles = append(s.BossProjectiles, bp.State())
+	}
+	if g.boss != nil {
+		boss := g.boss.State()
+		s.Boss = &boss
+	}
+	for name, t := range g.runTimers() {
+		s.Timers[name] = (*t).State()
+	}
+
+	return s
+}
+
+// restoreRun replaces the current run with s. The game must already have
+// been reset to a fresh run.
+func (g *Game) restoreRun(s *runSnapshot) {
+	g.queueSeed(s.Seed)
+	g.seedRun()
+	g.rngSource.skip(s.Draws)
+
+	g.runUpgrades = copyUpgrades(s.Upgrades)
+	g.difficulty = config.DifficultyFromKey(s.Difficulty)
+	g.mode = config.GameModeFromKey(s.Mode)
+	g.setOrientation(orientationFor(s.Portrait))
+	g.runTicks = s.RunTicks
+	g.recorder = nil
+	if s.Replay != "" {
+		if r, err := systems.ReplayFromJSON(s.Replay); err == nil {
+			g.recorder = r
+		}
+	}
+
+	g.score = s.Score
+	g.combo = s.Combo
+	g.wave = s.Wave
+	g.timeAttackHits = s.TimeAttackHits
+	g.timeAttackPenalty = s.TimeAttackPenalty
+	g.daily = nil
+	if s.Daily != "" {
+		daily := systems.NewDailyChallenge(s.Daily)
+		g.daily = &daily
+	}
+	g.dailyScored = s.DailyScored
+	g.meteorsDestroyed = s.MeteorsDestroyed
+	g.powerUpsCollected = s.PowerUpsCollected
+	g.gameStartTime = time.Now().Add(-s.Elapsed)
+
+	g.player.SetSkin(s.Skin)
+	g.player.SetBaseLives(g.difficulty.Preset().Lives)
+	g.setupCoop()
+	g.player.Restore(s.Player)
+	if g.coop != nil && s.Coop != nil {
+		g.coop.partner.SetSkin(s.Skin)
+		g.coop.partner.Restore(s.Coop.Partner)
+		g.coop.combo = s.Coop.Combo
+		g.coop.scores = s.Coop.Scores
+		g.coop.meteors = s.Coop.Meteors
+		g.coop.knockedOut = s.Coop.KnockedOut
+	}
+
+	for _, ms := range s.Meteors {
+		m := g.meteorPool.Get()
+		m.Restore(ms)
+		g.meteors = append(g.meteors, m)
+	}
+	for _, ls := range s.Lasers {
+		g.lasers = append(g.lasers, entities.RestoreLaser(ls))
+	}
+	for _, ps := range s.PowerUps {
+		p := g.powerUpPool.Get()
+		p.Restore(ps)
+		g.powerUps = append(g.powerUps, p)
+	}
+	for _, cs := range s.Coins {
+		g.coins = append(g.coins, entities.RestoreCoin(cs))
+	}
+	for _, bps := range s.BossProjectiles {
+		bp := g.bossProjectilePool.Get()
+		bp.Restore(bps)
+		g.bossProjectiles = append(g.bossProjectiles, bp)
+	}
+
+	g.boss = nil
+	if s.Boss != nil {
+		g.boss = entities.RestoreBoss(*s.Boss, g.bosses)
+		g.bossBar.Show()
+	}
+
+	g.bossWarningShown = s.BossWarningShown
+	g.bossAnnouncementTimer = s.BossAnnouncementTimer
+	g.bossDefeated = s.BossDefeated
+	g.bossCount = s.BossCount
+	g.bossNoDamage = s.BossNoDamage
+	g.isPostBossInvincible = s.PostBossInvincible
+	g.superPowerActive = s.SuperPowerActive
+	g.slowMotionActive = s.SlowMotionActive
+	g.laserBeamActive = s.LaserBeamActive
+	g.nukeActive = s.NukeActive
+	g.multiplierActive = s.MultiplierActive
+
+	for name, t := range g.runTimers() {
+		if ts, ok := s.Timers[name]; ok {
+			*t = systems.RestoreTimer(ts)
+		}
+	}
+}
+
+func isRunState(state config.GameState) bool {
+	switch state {
+	case config.StatePlaying, config.StateBossAnnouncement, config.StateBossFight:
+		return true
+	}
+	return false
+}
+
+// suspendRun writes the current run to storage so it can be continued
+// after a restart. It does nothing outside of a run.
+func (g *Game) suspendRun() {
+	if g.headless {
+		return
+	}
+
+	state := g.state
+	switch state {
+	case config.StatePaused, config.StateShop, config.StateSettings:
+		state = g.pausedRunState
+	}
+	if !isRunState(state) {
+		return
+	}
+
+	data, err := json.Marshal(g.snapshotRun(state))
+	if err != nil {
+		return
+	}
+	if g.storage.SaveRun(string(data)) == nil {
+		g.hasSuspendedRun = true
+	}
+}
+
+func (g *Game) clearSuspendedRun() {
+	if g.hasSuspendedRun {
+		g.storage.ClearRun()
+		g.hasSuspendedRun = false
+	}
+}
+
+func (g *Game) loadSuspendedRun() *runSnapshot {
+	data, err := g.storage.LoadRun()
+	if err != nil || data == "" {
+		return nil
+	}
+	var s runSnapshot
+	if err := json.Unmarshal([]byte(data), &s); err != nil || s.Version != GameVersion || !isRunState(s.State) {
+		return nil
+	}
+	// Like a replay, a run only plays on the same way under the wave table
+	// it started with. One from another table can never be continued.
+	if s.Waves != g.waves.Checksum() {
+		g.storage.ClearRun()
+		return nil
+	}
+	return &s
+}
+
+// continueRun resumes the suspended run into the pause menu so the player
+// can get ready before play continues.
+func (g *Game) continueRun() {
+	s := g.loadSuspendedRun()
+	if s == nil {
+		g.clearSuspendedRun()
+		return
+	}
+
+	g.mode = config.GameModeFromKey(s.Mode)
+	g.prepareGameReset()
+	g.restoreRun(s)
+	g.stateBeforePause = s.State
+	g.pausedRunState = s.State
+	g.state = config.StatePaused
+}
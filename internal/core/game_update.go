@@ -1,11 +1,12 @@
 package core
 
 import (
-	"math/rand"
 	"time"
 
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
 	"go-meteor/internal/entities"
+	"go-meteor/internal/input"
 	"go-meteor/internal/systems"
 	"go-meteor/internal/ui"
 
@@ -14,10 +15,32 @@ import (
 )
 
 func (g *Game) Update() error {
+	if ebiten.IsWindowBeingClosed() {
+		g.suspendRun()
+		return ebiten.Termination
+	}
+
+	g.controls = g.inputSource.Poll()
+	if g.coop != nil {
+		g.coop.controls = g.partnerSource.Poll()
+	}
+	g.followViewport()
 	g.updateStars()
+	g.updateGamepads()
+	g.handleFullscreenKey()
+	g.handleDebugKey()
+
+	state := g.state
+	if isRunState(state) {
+		if g.timeAttackOver() {
+			g.finishTimeAttack()
+			return nil
+		}
+		g.runTicks++
+	}
 
 	var err error
-	switch g.state {
+	switch state {
 	case config.StateMenu:
 		err = g.updateMenu()
 	case config.StatePlaying:
@@ -36,14 +59,21 @@ func (g *Game) Update() error {
 		err = g.updateSettings()
 	case config.StatePlayerDeath:
 		err = g.updatePlayerDeath()
+	case config.StateReplay:
+		err = g.updateReplay()
 	case config.StateWaitingNameInput:
 		return nil
 	}
+
+	g.recordTick(state)
 	return err
 }
 
 func (g *Game) updateMenu() error {
 	g.menu.SetScores(g.highScore, g.lastScore)
+	g.menu.SetReplayAvailable(g.lastReplay != nil)
+	g.menu.SetContinueAvailable(g.hasSuspendedRun)
+	g.menu.SetDailyInfo(g.dailyMenuInfo())
 
 	touchIDs := ebiten.AppendTouchIDs(nil)
 	g.detectMobileTouch(touchIDs)
@@ -60,8 +90,22 @@ func (g *Game) updateMenu() error {
 		return nil
 	}
 
+	if g.menu.ShouldWatchReplay() {
+		g.openReplay()
+		return nil
+	}
+
+	if g.menu.ShouldContinueRun() {
+		g.continueRun()
+		return nil
+	}
+
 	if g.menu.IsReady() {
+		g.difficulty = g.menu.Difficulty()
+		g.mode = g.menu.Mode()
+		g.clearSuspendedRun()
 		g.initNewGameSession()
+		g.claimDailyAttempt()
 		g.state = config.StatePlaying
 	}
 
@@ -70,8 +114,7 @@ func (g *Game) updateMenu() error {
 
 func (g *Game) updatePlaying() error {
 	if g.shouldPause() {
-		g.stateBeforePause = config.StatePlaying
-		g.state = config.StatePaused
+		g.pause(config.StatePlaying)
 		return nil
 	}
 
@@ -84,55 +127,27 @@ func (g *Game) updatePlaying() error {
 	g.detectMobileTouch(touchIDs)
 	g.handleMobileControls(touchIDs)
 
-	g.player.Update()
+	g.updateShips()
 	g.notification.Update()
 
-	speedMultiplier := 1.0
-	if g.wave >= 20 {
-		speedMultiplier = 1.0 + float64(g.wave-20)*config.WaveDifficultyFactor
-	}
-	meteorsPerWave := max(config.MeteorsPerWaveOffset, config.MeteorsPerWaveOffset+(g.wave-1)/config.WaveMeteoIncrement)
+	meteors := g.meteorSettings()
+	powerUps := g.waves.PowerUpsAt(g.wave)
+	g.meteoSpawnTimer.SetDuration(meteors.SpawnInterval)
+	g.powerUpSpawnTimer.SetDuration(g.powerUpInterval(powerUps))
+
+	// Boss Rush has no waves; this state is only the gap between bosses.
+	if !g.isBossRush() {
+		if !g.nukeActive {
+			g.updateAndSpawn(g.meteoSpawnTimer, func() {
+				g.spawnMeteors(meteors)
+			})
+		}
 
-	if !g.nukeActive {
-		g.updateAndSpawn(g.meteoSpawnTimer, func() {
-			for i := 0; i < meteorsPerWave; i++ {
-				m := g.meteorPool.Get()
-				m.Reset(speedMultiplier)
-				g.meteors = append(g.meteors, m)
-			}
+		g.updateAndSpawn(g.powerUpSpawnTimer, func() {
+			g.spawnPowerUpFrom(powerUps)
 		})
 	}
 
-	g.updateAndSpawn(g.powerUpSpawnTimer, func() {
-		var p *entities.PowerUp
-
-		if g.wave >= 5 && rand.Float64() < 0.50 {
-			p = entities.NewPowerUpWithType(entities.PowerUpExtraLife)
-		} else if g.wave >= config.MinWaveForLaser && rand.Float64() < 0.60 {
-			powerType := entities.PowerUpLaser
-			roll := rand.Float64()
-			if roll < 0.25 {
-				powerType = entities.PowerUpNuke
-			} else if roll < 0.50 {
-				powerType = entities.PowerUpMultiplier
-			}
-			p = entities.NewPowerUpWithType(powerType)
-		} else {
-			powerType := entities.PowerUpSuperShot
-			roll := rand.Float64()
-			if roll < 0.25 {
-				powerType = entities.PowerUpShield
-			} else if roll < 0.50 {
-				powerType = entities.PowerUpSlowMotion
-			} else if roll < 0.75 {
-				powerType = entities.PowerUpMultiplier
-			}
-			p = entities.NewPowerUpWithType(powerType)
-		}
-
-		g.powerUps = append(g.powerUps, p)
-	})
-
 	g.updateGameTimers()
 
 	for _, p := range g.powerUps {
@@ -141,6 +156,7 @@ func (g *Game) updatePlaying() error {
 
 	g.updateMeteors()
 	g.updateAllEntities()
+	g.attractCoins()
 	g.updateBossEntities()
 
 	playerDied := g.checkCollisions()
@@ -149,7 +165,7 @@ func (g *Game) updatePlaying() error {
 		return nil
 	}
 
-	g.player.UpdateTimers()
+	g.updateShipTimers()
 	g.cleanObjects()
 
 	g.screenShake = max(0, g.screenShake-1)
@@ -165,15 +181,13 @@ func (g *Game) updatePaused() error {
 
 	switch action {
 	case ui.PauseActionContinue:
-		if g.stateBeforePause != 0 {
-			g.state = g.stateBeforePause
-		} else {
-			g.state = config.StatePlaying
-		}
+		g.unpause()
 	case ui.PauseActionRestart:
+		g.clearSuspendedRun()
 		g.Reset()
 		g.state = config.StatePlaying
 	case ui.PauseActionQuit:
+		g.suspendRun()
 		g.returnToMenu()
 	case ui.PauseActionSettings:
 		g.openSettings(config.StatePaused)
@@ -181,19 +195,26 @@ func (g *Game) updatePaused() error {
 		g.openShop(config.StatePaused)
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyP) {
-		if g.stateBeforePause != 0 {
-			g.state = g.stateBeforePause
-		} else {
-			g.state = config.StatePlaying
-		}
+	if g.controls.Pause || (g.coop != nil && g.coop.controls.Pause) {
+		g.unpause()
 	}
 
 	return nil
 }
 
+// unpause drops the suspended copy of the run; it is written again on the
+// next pause, so an old snapshot can't be used to undo mistakes.
+func (g *Game) unpause() {
+	if g.stateBeforePause != 0 {
+		g.state = g.stateBeforePause
+	} else {
+		g.state = config.StatePlaying
+	}
+	g.clearSuspendedRun()
+}
+
 func (g *Game) updateGameOver() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+	if g.shopRequested() {
 		g.openShop(config.StateGameOver)
 		return nil
 	}
@@ -206,7 +227,7 @@ func (g *Game) updateGameOver() error {
 		return nil
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+	if input.ActionJustPressed(input.ActionConfirm) {
 		g.startNewGame()
 		return nil
 	}
@@ -219,8 +240,8 @@ func (g *Game) updateGameOver() error {
 }
 
 func (g *Game) startNewGame() {
+	g.clearSuspendedRun()
 	g.prepareGameReset()
-	g.initNewGameSession()
 	g.state = config.StatePlaying
 }
 
@@ -239,7 +260,7 @@ func (g *Game) handleGameOverMouseClick() bool {
 		return true
 	}
 
-	if g.isMobile && g.isShopButtonClicked(ebiten.CursorPosition()) {
+	if g.isMobile && g.isShopButtonClicked(display.CursorPosition()) {
 		g.openShop(config.StateGameOver)
 		return true
 	}
@@ -253,20 +274,27 @@ func (g *Game) isShopButtonClicked(x, y int) bool {
 }
 
 func (g *Game) handleGameOverTouch() bool {
-	touchIDs := inpututil.AppendJustPressedTouchIDs(nil)
-	if len(touchIDs) == 0 {
+	if len(inpututil.AppendJustPressedTouchIDs(nil)) == 0 {
 		return false
 	}
+	g.startNewGame()
+	return true
+}
 
-	for _, id := range touchIDs {
-		x, y := ebiten.TouchPosition(id)
+// shopRequested reports whether either player asked for the shop on the
+// game-over screen, through the bound action or its touch button.
+func (g *Game) shopRequested() bool {
+	if g.controls.Shop || (g.coop != nil && g.coop.controls.Shop) {
+		return true
+	}
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := display.TouchPosition(id)
 		if g.isShopButtonClicked(x, y) {
-			g.openShop(config.StateGameOver)
+			g.controls.Shop = true
 			return true
 		}
 	}
-	g.startNewGame()
-	return true
+	return false
 }
 
 func (g *Game) openShop(previousState config.GameState) {
@@ -297,9 +325,10 @@ func (g *Game) updateShop() error {
 		g.stateBeforePause = 0
 	case ui.ShopActionUpgrade:
 		powerType := g.shop.GetUpgradeType()
-		item := g.getShopItem(powerType)
-		if item != nil && g.progress.Coins >= item.NextCost {
-			g.progress.SpendCoins(item.NextCost)
+		upgrade, ok := systems.UpgradeByID(powerType)
+		cost := upgrade.CostAt(g.progress.GetUpgradeLevel(powerType))
+		if ok && cost > 0 && g.progress.Coins >= cost {
+			g.progress.SpendCoins(cost)
 			g.progress.UpgradePower(powerType)
 			g.saveProgress()
 			g.shop.SetProgress(g.progress)
@@ -316,7 +345,8 @@ func (g *Game) updateShop() error {
 		if g.progress.EquipSkin(skinID) {
 			g.saveProgress()
 			g.shop.SetProgress(g.progress)
-			if g.player != nil {
+			// A run keeps the skin it started with so its replay stays valid.
+			if g.player != nil && g.stateBeforePause != config.StatePaused {
 				g.player.SetSkin(skinID)
 			}
 		}
@@ -325,39 +355,18 @@ func (g *Game) updateShop() error {
 	return nil
 }
 
-func (g *Game) getShopItem(powerType string) *ui.ShopItem {
-	for i := range g.shop.Items {
-		if g.shop.Items[i].PowerType == powerType {
-			return &g.shop.Items[i]
-		}
-	}
-	return nil
-}
-
-func (g *Game) findSkin(skinID string) *ui.SkinItem {
-	for i := range g.shop.Skins {
-		if g.shop.Skins[i].ID == skinID {
-			return &g.shop.Skins[i]
-		}
-	}
-	return nil
-}
-
 func (g *Game) getSkinCost(skinID string) int {
-	if skin := g.findSkin(skinID); skin != nil {
+	if skin, ok := systems.SkinByID(skinID); ok {
 		return skin.Cost
 	}
 	return 0
 }
 
-func (g *Game) getSkinItem(skinID string) *ui.SkinItem {
-	return g.findSkin(skinID)
-}
-
 func (g *Game) updateSettings() error {
 	g.settingsMenu.Update()
 
 	if g.settingsMenu.IsClosed() {
+		g.saveSettings()
 		if g.stateBeforePause != 0 {
 			g.state = g.stateBeforePause
 			g.stateBeforePause = 0
@@ -386,15 +395,10 @@ func (g *Game) updatePlayerDeath() error {
 
 	if g.playerDeathTimer >= config.PlayerDeathAnimationDuration {
 		g.survivalTime = time.Since(g.gameStartTime)
-		g.statistics = ui.NewStatistics(g.meteorsDestroyed, g.powerUpsCollected, g.wave, g.score, g.survivalTime)
+		g.statistics = g.newStatistics()
 		g.saveHighScore()
-
-		if g.leaderboard.IsTopScore(g.score) && g.hasNameInputModal() {
-			g.state = config.StateWaitingNameInput
-			g.showNameInputModal()
-		} else {
-			g.state = config.StateGameOver
-		}
+		g.finishRecording()
+		g.enterResults()
 	}
 
 	return nil
@@ -402,7 +406,7 @@ func (g *Game) updatePlayerDeath() error {
 
 func (g *Game) updateStars() {
 	g.updateAndSpawn(g.starSpawnTimer, func() {
-		g.stars = append(g.stars, entities.NewStar())
+		g.stars = append(g.stars, entities.NewStar(g.fxRng))
 	})
 
 	for _, s := range g.stars {
@@ -434,9 +438,9 @@ func (g *Game) updateGameTimers() {
 	g.updatePowerTimer(&g.nukeActive, g.nukeTimer)
 	g.updatePowerTimer(&g.multiplierActive, g.multiplierTimer)
 
-	g.comboTimer.Update()
-	if g.comboTimer.IsReady() && g.combo > 0 && !g.nukeActive {
-		g.combo = 0
+	g.updateCombo(&g.combo, g.comboTimer)
+	if g.coop != nil {
+		g.updateCombo(&g.coop.combo, g.coop.comboTimer)
 	}
 
 	if g.bossDefeated {
@@ -454,6 +458,13 @@ func (g *Game) updateGameTimers() {
 	}
 }
 
+func (g *Game) updateCombo(combo *int, timer *systems.Timer) {
+	timer.Update()
+	if timer.IsReady() && *combo > 0 && !g.nukeActive {
+		*combo = 0
+	}
+}
+
 func (g *Game) updatePowerTimer(active *bool, timer *systems.Timer) {
 	if !*active {
 		return
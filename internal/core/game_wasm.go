@@ -9,12 +9,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"go-meteor/internal/config"
+	"go-meteor/internal/systems"
 	"syscall/js"
 	"time"
 )
 
-const GameVersion = "0.2.0"
-
 func getSecretKey() []byte {
 	encrypted := []byte{
 		0x67, 0x6f, 0x2d, 0x6d, 0x65, 0x74, 0x65, 0x6f, 0x72, 0x2d,
@@ -28,8 +27,8 @@ func getSecretKey() []byte {
 	return key
 }
 
-func generateSignature(name string, score int, sessionToken string, timestamp int64) string {
-	message := fmt.Sprintf("%s|%d|%s|%d", name, score, sessionToken, timestamp)
+func generateSignature(name string, score int, category systems.LeaderboardCategory, sessionToken string, timestamp int64) string {
+	message := fmt.Sprintf("%s|%d|%s|%s|%s|%s|%d", name, score, category.Mode, category.Difficulty, category.Challenge, sessionToken, timestamp)
 	h := hmac.New(sha256.New, getSecretKey())
 	h.Write([]byte(message))
 	return hex.EncodeToString(h.Sum(nil))
@@ -52,10 +51,18 @@ func (g *Game) notifyWebLeaderboard(name string, score int) {
 
 	sessionToken := sessionTokenValue.String()
 	timestamp := time.Now().UnixMilli()
-	signature := generateSignature(name, score, sessionToken, timestamp)
+	category := g.leaderboardCategory()
+	signature := generateSignature(name, score, category, sessionToken, timestamp)
+
+	replayData := ""
+	if g.lastReplay != nil {
+		if data, err := g.lastReplay.ToJSON(); err == nil {
+			replayData = data
+		}
+	}
 
 	js.Global().Get("console").Call("log", "[Security] Sending score with HMAC signature")
-	updateFunc.Invoke(name, score, signature, timestamp)
+	updateFunc.Invoke(name, score, signature, timestamp, replayData, category.Difficulty, category.Mode, category.Challenge)
 }
 
 func (g *Game) showNameInputModal() {
@@ -80,7 +87,8 @@ func (g *Game) showNameInputModal() {
 			return nil
 		})
 
-		promise := isTopScore.Invoke(g.score)
+		category := g.leaderboardCategory()
+		promise := isTopScore.Invoke(g.score, category.Difficulty, category.Mode, category.Challenge)
 		promise.Call("then", promiseCallback)
 	} else {
 		g.showModalInternal()
@@ -107,7 +115,7 @@ func (g *Game) showModalInternal() {
 
 		if len(args) > 0 && args[0].String() != "" {
 			name := args[0].String()
-			g.leaderboard.AddScore(name, g.score)
+			g.leaderboard.AddScore(name, g.score, g.leaderboardCategory())
 
 			data, err := g.leaderboard.ToJSON()
 			if err == nil {
@@ -129,10 +137,7 @@ func (g *Game) hasNameInputModal() bool {
 }
 
 func (g *Game) initNewGameSession() {
-	g.meteorsDestroyed = 0
-	g.powerUpsCollected = 0
-	g.gameStartTime = time.Now()
-	g.survivalTime = 0
+	g.beginSession()
 
 	initFunc := js.Global().Get("initGameSession")
 	if !initFunc.IsUndefined() && !initFunc.IsNull() {
@@ -140,3 +145,30 @@ func (g *Game) initNewGameSession() {
 		js.Global().Get("console").Call("log", "[Game] New session token requested")
 	}
 }
+
+func (g *Game) handleFullscreenKey() {
+}
+
+// viewportOrientation reads the browser viewport: portrait when it is
+// taller than it is wide, as on a phone held upright.
+func viewportOrientation() config.Orientation {
+	w := js.Global().Get("innerWidth")
+	h := js.Global().Get("innerHeight")
+	if w.Type() != js.TypeNumber || h.Type() != js.TypeNumber {
+		return config.Landscape
+	}
+	return orientationFor(h.Float() > w.Float())
+}
+
+// registerSuspendHandler saves the run when the tab is hidden or closed;
+// the game loop stops running before a window close could be seen.
+func (g *Game) registerSuspendHandler() {
+	handler := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if js.Global().Get("document").Get("hidden").Bool() {
+			g.suspendRun()
+		}
+		return nil
+	})
+	js.Global().Get("document").Call("addEventListener", "visibilitychange", handler)
+	js.Global().Call("addEventListener", "pagehide", handler)
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/entities"
+	"go-meteor/internal/systems"
+)
+
+// loadWaves reads the wave table once per process. A broken override is
+// reported and the built-in table is used instead.
+var loadWaves = sync.OnceValue(func() *systems.WaveTable {
+	table, err := systems.LoadWaveTable(entities.IsPowerUpName)
+	if table == nil {
+		panic(err)
+	}
+	if err != nil {
+		log.Println("Error: ignoring wave override", err)
+	} else if table.IsOverride() {
+		log.Println("Using wave override", table.Checksum())
+	}
+	return table
+})
+
+// meteorSettings is the wave table entry for the current wave scaled by the
+// run's difficulty.
+func (g *Game) meteorSettings() systems.MeteorSettings {
+	settings := g.waves.MeteorsAt(g.wave)
+	preset := g.difficulty.Preset()
+	settings.SpeedMultiplier *= preset.MeteorSpeed
+	settings.SpawnInterval = time.Duration(float64(settings.SpawnInterval) / preset.SpawnRate)
+	if g.daily.Has(systems.ModifierIceOnly) {
+		settings.Mix = systems.MeteorMix{Ice: 1}
+	}
+	if g.daily.Has(systems.ModifierFastMeteors) {
+		settings.SpeedMultiplier *= config.DailyFastMeteorSpeed
+	}
+	return settings
+}
+
+func (g *Game) powerUpInterval(table *systems.PowerUpWave) time.Duration {
+	return time.Duration(float64(table.SpawnInterval()) / g.difficulty.Preset().PowerUpRate)
+}
+
+func (g *Game) spawnMeteors(settings systems.MeteorSettings) {
+	for i := 0; i < settings.Count; i++ {
+		m := g.meteorPool.Get()
+		m.Reset(g.rng, settings.SpeedMultiplier, settings.Mix)
+		g.meteors = append(g.meteors, m)
+	}
+}
+
+func (g *Game) spawnPowerUpFrom(table *systems.PowerUpWave) {
+	if g.daily.Has(systems.ModifierNoShields) {
+		table = table.Without("shield")
+	}
+	// Names are checked when the table loads, so the lookup cannot miss.
+	powerType, _ := entities.PowerUpTypeFromName(table.Pick(g.rng))
+	g.powerUps = append(g.powerUps, entities.NewPowerUpWithType(g.rng, powerType))
+}
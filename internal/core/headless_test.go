@@ -0,0 +1,84 @@
+package core
+
+import (
+	"testing"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/input"
+)
+
+// weave sweeps the ship across the field while firing, so runs meet
+// meteors, power-ups and eventually a boss.
+func weave(tick int) input.Controls {
+	left := (tick/90)%2 == 0
+	return input.Controls{Left: left, Right: !left, Shoot: tick%4 != 0}
+}
+
+func TestHeadlessDeterministic(t *testing.T) {
+	a, b := NewHeadless(42), NewHeadless(42)
+	for tick := 0; tick < 4000; tick++ {
+		if err := a.Step(weave(tick)); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Step(weave(tick)); err != nil {
+			t.Fatal(err)
+		}
+		if sa, sb := a.Stats(), b.Stats(); sa != sb {
+			t.Fatalf("tick %d: runs diverged\n%+v\n%+v", tick, sa, sb)
+		}
+	}
+	if a.Stats().Score == 0 {
+		t.Error("scripted run scored nothing; the test no longer exercises collisions")
+	}
+}
+
+func TestHeadlessSeedsDiffer(t *testing.T) {
+	a, b := NewHeadless(1), NewHeadless(2)
+	if err := a.Run(600, input.Controls{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Run(600, input.Controls{}); err != nil {
+		t.Fatal(err)
+	}
+	ma, mb := a.Game().meteors, b.Game().meteors
+	if len(ma) == 0 || len(mb) == 0 {
+		t.Fatal("no meteors spawned")
+	}
+	if ma[0].GetPosition() == mb[0].GetPosition() {
+		t.Error("different seeds spawned the same first meteor")
+	}
+}
+
+func TestHeadlessMeteorsSpawnAndCollide(t *testing.T) {
+	h := NewHeadless(7)
+
+	spawned, err := h.RunUntil(600, input.Controls{}, func(s HeadlessStats) bool {
+		return s.Meteors > 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !spawned {
+		t.Fatal("no meteor spawned in 10 seconds")
+	}
+
+	for tick := 0; h.Stats().Score == 0; tick++ {
+		if tick == 3000 {
+			t.Fatal("lasers never destroyed a meteor")
+		}
+		if err := h.Step(weave(tick)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lives := h.Stats().Lives
+	hit, err := h.RunUntil(20000, input.Controls{}, func(s HeadlessStats) bool {
+		return s.Lives < lives || s.State != config.StatePlaying
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hit {
+		t.Fatal("an idle ship was never hit by a meteor")
+	}
+}
@@ -48,6 +48,9 @@ func (g *Game) updateBossEntities() {
 }
 
 func (g *Game) detectMobileTouch(touchIDs []ebiten.TouchID) {
+	if g.headless {
+		return
+	}
 	if len(touchIDs) > 0 && !g.touchDetected {
 		g.touchDetected = true
 		g.isMobile = true
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/systems"
+)
+
+// recordedRun plays a short headless run and returns its replay as it
+// would come back from storage.
+func recordedRun(t *testing.T) *systems.Replay {
+	t.Helper()
+	h := NewHeadless(11)
+	for tick := 0; tick < 1500; tick++ {
+		if err := h.Step(weave(tick)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g := h.Game()
+	if g.recorder == nil {
+		t.Fatal("run ended before it could be recorded")
+	}
+	g.recorder.Finish(g.score)
+
+	data, err := g.recorder.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := systems.ReplayFromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestVerifyReplayAcceptsRecordedRun(t *testing.T) {
+	r := recordedRun(t)
+	if r.FinalScore == 0 {
+		t.Fatal("recorded run scored nothing")
+	}
+	score, ok := VerifyReplay(r)
+	if !ok {
+		t.Errorf("VerifyReplay reached %d, recorded %d", score, r.FinalScore)
+	}
+}
+
+func TestVerifyReplayRejectsTamperedScore(t *testing.T) {
+	r := recordedRun(t)
+	r.FinalScore += 100
+	if score, ok := VerifyReplay(r); ok {
+		t.Errorf("tampered score %d verified, re-simulation reached %d", r.FinalScore, score)
+	}
+}
+
+func TestReplayViewerRestoresOrientation(t *testing.T) {
+	defer config.SetFieldOrientation(config.FieldOrientation())
+	r := recordedRun(t)
+	r.Portrait = true
+
+	g := NewHeadless(1).Game()
+	g.lastReplay = r
+	config.SetFieldOrientation(config.Landscape)
+	g.openReplay()
+	if got := config.FieldOrientation(); got != config.Portrait {
+		t.Fatalf("replay plays on orientation %d, want portrait", got)
+	}
+	g.closeReplay()
+	if got := config.FieldOrientation(); got != config.Landscape {
+		t.Errorf("orientation after closing the replay = %d, want landscape", got)
+	}
+}
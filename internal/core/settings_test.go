@@ -0,0 +1,85 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/input"
+	"go-meteor/internal/systems"
+	assets "go-meteor/src/pkg"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Headless and replay games share the process with the player's game, so
+// they must not replace its bindings or volumes.
+func TestNonInteractiveGamesKeepSettings(t *testing.T) {
+	savedBindings := input.CurrentBindings()
+	savedVolume := assets.GetMasterVolume()
+	defer func() {
+		input.SetBindings(savedBindings)
+		assets.SetMasterVolume(savedVolume)
+	}()
+
+	custom := input.DefaultBindings()
+	custom.SetKey(input.ActionShoot, 0, ebiten.KeyJ)
+	input.SetBindings(custom)
+	assets.SetMasterVolume(0.3)
+
+	h := NewHeadless(1)
+	if err := h.Run(60, input.Controls{Shoot: true}); err != nil {
+		t.Fatal(err)
+	}
+	h.game.loadSettings()
+	h.game.saveSettings()
+
+	r := systems.NewReplay(GameVersion, 1, systems.DefaultSkin, nil)
+	r.Waves = loadWaves().Checksum()
+	r.Finish(0)
+	VerifyReplay(r)
+	newReplayGame(r)
+
+	got := input.CurrentBindings()
+	if !reflect.DeepEqual(got.Names(), custom.Names()) {
+		t.Errorf("bindings changed to %v", got.Names())
+	}
+	if v := assets.GetMasterVolume(); v != 0.3 {
+		t.Errorf("master volume changed to %v", v)
+	}
+}
+
+// The shop shortcut goes through the controls, so a rebound key, a
+// gamepad or the partner's pad reaches it like any other action.
+func TestShopActionOpensShopFromGameOver(t *testing.T) {
+	for _, partner := range []bool{false, true} {
+		h := NewHeadless(1)
+		g := h.game
+		if partner {
+			g.mode = config.ModeCoop
+			g.beginSession()
+		}
+		g.state = config.StateGameOver
+
+		if err := h.StepCoop(input.Controls{}, input.Controls{}); err != nil {
+			t.Fatal(err)
+		}
+		if g.state != config.StateGameOver {
+			t.Fatalf("partner=%v: left game over to %v without input", partner, g.state)
+		}
+
+		shop := input.Controls{Shop: true}
+		var err error
+		if partner {
+			err = h.StepCoop(input.Controls{}, shop)
+		} else {
+			err = h.Step(shop)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.state != config.StateShop || g.stateBeforePause != config.StateGameOver {
+			t.Errorf("partner=%v: state %v after the shop action, want the shop over game over", partner, g.state)
+		}
+	}
+}
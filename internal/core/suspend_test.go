@@ -0,0 +1,63 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/input"
+)
+
+// suspendedGame plays a little of a run and writes it to storage as if the
+// window had closed, with waves as the snapshot's wave table checksum.
+func suspendedGame(t *testing.T, waves string) *Game {
+	h := NewHeadless(1)
+	if err := h.Run(120, input.Controls{Shoot: true, Left: true}); err != nil {
+		t.Fatal(err)
+	}
+	g := h.game
+	s := g.snapshotRun(config.StatePlaying)
+	if s.Waves != g.waves.Checksum() {
+		t.Fatalf("snapshot waves = %q, want %q", s.Waves, g.waves.Checksum())
+	}
+	s.Waves = waves
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.storage.SaveRun(string(data)); err != nil {
+		t.Fatal(err)
+	}
+	g.hasSuspendedRun = true
+	return g
+}
+
+func TestContinueRunUnderSameWaves(t *testing.T) {
+	g := suspendedGame(t, loadWaves().Checksum())
+	ticks := g.runTicks
+
+	g.continueRun()
+	if g.state != config.StatePaused || g.pausedRunState != config.StatePlaying {
+		t.Fatalf("continued into %v over %v, want paused over playing", g.state, g.pausedRunState)
+	}
+	if g.runTicks != ticks {
+		t.Errorf("run ticks = %d, want %d", g.runTicks, ticks)
+	}
+}
+
+func TestSuspendedRunFromOtherWavesIsDropped(t *testing.T) {
+	g := suspendedGame(t, "retuned")
+	ticks := g.runTicks
+
+	if g.loadSuspendedRun() != nil {
+		t.Fatal("run from another wave table loaded")
+	}
+	if data, _ := g.storage.LoadRun(); data != "" {
+		t.Error("run from another wave table kept in storage")
+	}
+
+	g.continueRun()
+	if g.state == config.StatePaused || g.runTicks != ticks || g.hasSuspendedRun {
+		t.Error("run from another wave table was continued")
+	}
+}
@@ -0,0 +1,267 @@
+// Package display maps the fixed virtual resolution the game is designed
+// and simulated at onto windows and screens of any shape.
+//
+// The playfield is drawn to a field canvas the size of the current
+// config.FieldWidth by config.FieldHeight, and menus to a UI canvas of
+// config.ScreenWidth by config.ScreenHeight. The view is the field widened
+// or heightened to the window's aspect ratio, in the same virtual units;
+// the field sits at its centre, or above the control strip in portrait,
+// and the HUD is anchored to its edges. The UI canvas is centred over it
+// and shrunk where the view is too narrow for it.
+package display
+
+import (
+	"image/color"
+	"math"
+
+	"go-meteor/internal/config"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Options are the player's display settings. systems.DisplaySettings
+// saves them and must keep the same fields.
+type Options struct {
+	// RenderScale is the share of the screen's native pixels rendered,
+	// from MinRenderScale to 1. Lower is faster on weak devices.
+	RenderScale float64
+	// IntegerScale only scales the canvas by whole numbers, for crisp
+	// pixels at the cost of wider borders.
+	IntegerScale bool
+	Fullscreen   bool
+}
+
+const MinRenderScale = 0.5
+
+func DefaultOptions() Options {
+	return Options{RenderScale: 1}
+}
+
+// Anchor is the edge or corner of the view a HUD element keeps its
+// distance to.
+type Anchor int
+
+const (
+	TopLeft Anchor = iota
+	TopCenter
+	TopRight
+	Center
+	BottomLeft
+	BottomCenter
+	BottomRight
+	// ControlsLeft and ControlsRight are the bottom corners of the whole
+	// view, for the touch controls. In portrait they are in the control
+	// strip, below the edge the other Bottom anchors keep to.
+	ControlsLeft
+	ControlsRight
+)
+
+type view struct {
+	width, height  float64
+	fieldX, fieldY float64
+	// uiX, uiY and uiScale place the UI canvas in the view.
+	uiX, uiY, uiScale float64
+	// hudBottom is the edge Bottom anchors keep to: the bottom of the view,
+	// or of the field in portrait.
+	hudBottom float64
+	// scale is screen pixels per virtual unit.
+	scale float64
+	opts  Options
+}
+
+var current = view{
+	width:     config.ScreenWidth,
+	height:    config.ScreenHeight,
+	uiScale:   1,
+	hudBottom: config.ScreenHeight,
+	scale:     1,
+	opts:      DefaultOptions(),
+}
+
+func CurrentOptions() Options {
+	return current.opts
+}
+
+// SetOptions applies o from the next Layout on. Fullscreen is applied at
+// once where the platform supports it.
+func SetOptions(o Options) {
+	o.RenderScale = max(MinRenderScale, min(1, o.RenderScale))
+	current.opts = o
+	if FullscreenSupported {
+		ebiten.SetFullscreen(o.Fullscreen)
+	}
+}
+
+// Layout sizes the screen for a window of outsideWidth by outsideHeight
+// device-independent pixels and recomputes the view to match.
+func Layout(outsideWidth, outsideHeight int) (int, int) {
+	scale := ebiten.Monitor().DeviceScaleFactor() * current.opts.RenderScale
+	w := max(1, math.Ceil(float64(outsideWidth)*scale))
+	h := max(1, math.Ceil(float64(outsideHeight)*scale))
+
+	fieldW, fieldH := config.FieldWidth(), config.FieldHeight()
+	strip := StripHeight()
+	fit := min(w/fieldW, h/(fieldH+strip))
+	if current.opts.IntegerScale && fit >= 1 {
+		fit = math.Floor(fit)
+	}
+
+	current.scale = fit
+	current.width = w / fit
+	current.height = h / fit
+	current.hudBottom = current.height - strip
+	current.fieldX = (current.width - fieldW) / 2
+	current.fieldY = (current.hudBottom - fieldH) / 2
+	current.uiScale = min(1, current.width/config.ScreenWidth, current.height/config.ScreenHeight)
+	current.uiX = (current.width - config.ScreenWidth*current.uiScale) / 2
+	current.uiY = (current.height - config.ScreenHeight*current.uiScale) / 2
+	return int(w), int(h)
+}
+
+// StripHeight is the height of the control strip below a portrait field,
+// or 0 in landscape.
+func StripHeight() float64 {
+	if config.FieldOrientation() == config.Portrait {
+		return config.ControlStripHeight
+	}
+	return 0
+}
+
+// StripTop is where the control strip starts in the view. It is the
+// bottom of the view when there is none.
+func StripTop() float64 {
+	return current.hudBottom
+}
+
+// Width and Height are the view's size in virtual units. Neither is ever
+// smaller than the field.
+func Width() float64 {
+	return current.width
+}
+
+func Height() float64 {
+	return current.height
+}
+
+// Place maps a point laid out for the canvas to the view, keeping its
+// distance to the edges named by a.
+func Place(a Anchor, x, y float64) (float64, float64) {
+	dx, dy := current.shift(a)
+	return x + dx, y + dy
+}
+
+// Unplace reverses Place.
+func Unplace(a Anchor, x, y float64) (float64, float64) {
+	dx, dy := current.shift(a)
+	return x - dx, y - dy
+}
+
+func (v view) shift(a Anchor) (float64, float64) {
+	var dx, dy float64
+	switch a {
+	case TopCenter, Center, BottomCenter:
+		dx = (v.width - config.ScreenWidth) / 2
+	case TopRight, BottomRight, ControlsRight:
+		dx = v.width - config.ScreenWidth
+	}
+	switch a {
+	case Center:
+		dy = (v.hudBottom - config.ScreenHeight) / 2
+	case BottomLeft, BottomCenter, BottomRight:
+		dy = v.hudBottom - config.ScreenHeight
+	case ControlsLeft, ControlsRight:
+		dy = v.height - config.ScreenHeight
+	}
+	return dx, dy
+}
+
+// CursorPosition is the mouse position on the UI canvas, for menus and
+// anything else drawn there.
+func CursorPosition() (int, int) {
+	return toCanvas(ebiten.CursorPosition())
+}
+
+func TouchPosition(id ebiten.TouchID) (int, int) {
+	return toCanvas(ebiten.TouchPosition(id))
+}
+
+// ViewCursorPosition is the mouse position in the view, for the HUD.
+func ViewCursorPosition() (int, int) {
+	return toView(ebiten.CursorPosition())
+}
+
+func ViewTouchPosition(id ebiten.TouchID) (int, int) {
+	return toView(ebiten.TouchPosition(id))
+}
+
+func toView(x, y int) (int, int) {
+	return int(float64(x) / current.scale), int(float64(y) / current.scale)
+}
+
+func toCanvas(x, y int) (int, int) {
+	vx, vy := ViewToCanvas(float64(x)/current.scale, float64(y)/current.scale)
+	return int(vx), int(vy)
+}
+
+// ViewToCanvas maps a point in the view to the UI canvas.
+func ViewToCanvas(x, y float64) (float64, float64) {
+	return (x - current.uiX) / current.uiScale, (y - current.uiY) / current.uiScale
+}
+
+var colorBorder = color.RGBA{40, 40, 70, 255}
+
+// Present draws the field in its place on screen, the UI canvas over it
+// and the view-sized HUD on top. The border outlines the field when the
+// view is wider or taller.
+func Present(screen, field, ui, hud *ebiten.Image) {
+	filter := ebiten.FilterLinear
+	if current.opts.IntegerScale {
+		filter = ebiten.FilterNearest
+	}
+
+	op := &ebiten.DrawImageOptions{Filter: filter}
+	op.GeoM.Translate(current.fieldX, current.fieldY)
+	op.GeoM.Scale(current.scale, current.scale)
+	screen.DrawImage(field, op)
+
+	fieldW, fieldH := config.FieldWidth(), config.FieldHeight()
+	if current.width-fieldW >= 1 || current.height-fieldH >= 1 {
+		x0, y0 := current.fieldX*current.scale, current.fieldY*current.scale
+		w, h := fieldW*current.scale, fieldH*current.scale
+		vector.StrokeRect(screen, float32(x0), float32(y0), float32(w), float32(h), 2, colorBorder, false)
+	}
+
+	op = &ebiten.DrawImageOptions{Filter: filter}
+	op.GeoM.Scale(current.uiScale, current.uiScale)
+	op.GeoM.Translate(current.uiX, current.uiY)
+	op.GeoM.Scale(current.scale, current.scale)
+	screen.DrawImage(ui, op)
+
+	op = &ebiten.DrawImageOptions{Filter: filter}
+	op.GeoM.Scale(current.scale, current.scale)
+	screen.DrawImage(hud, op)
+}
+
+// ViewImage returns img if it already matches the view's size, else a new
+// image that does.
+func ViewImage(img *ebiten.Image) *ebiten.Image {
+	return sized(img, int(math.Ceil(current.width)), int(math.Ceil(current.height)))
+}
+
+// FieldImage is ViewImage for the field canvas, which changes size with
+// the field's orientation.
+func FieldImage(img *ebiten.Image) *ebiten.Image {
+	return sized(img, int(config.FieldWidth()), int(config.FieldHeight()))
+}
+
+func sized(img *ebiten.Image, w, h int) *ebiten.Image {
+	if img != nil && img.Bounds().Dx() == w && img.Bounds().Dy() == h {
+		img.Clear()
+		return img
+	}
+	if img != nil {
+		img.Deallocate()
+	}
+	return ebiten.NewImage(w, h)
+}
@@ -0,0 +1,7 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package display
+
+// FullscreenSupported reports whether the Fullscreen option is offered.
+const FullscreenSupported = true
@@ -0,0 +1,8 @@
+//go:build js && wasm
+// +build js,wasm
+
+package display
+
+// FullscreenSupported is false in browsers, which only allow fullscreen
+// from a user gesture and offer their own toggle.
+const FullscreenSupported = false
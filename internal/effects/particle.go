@@ -18,9 +18,9 @@ type Particle struct {
 	maxLife  int
 }
 
-func NewParticle(pos systems.Vector) *Particle {
-	angle := rand.Float64() * 6.28318530718
-	speed := rand.Float64() * config.ParticleSpeed
+func NewParticle(rng *rand.Rand, pos systems.Vector) *Particle {
+	angle := rng.Float64() * 6.28318530718
+	speed := rng.Float64() * config.ParticleSpeed
 
 	return &Particle{
 		position: pos,
@@ -29,9 +29,9 @@ func NewParticle(pos systems.Vector) *Particle {
 			Y: speed * sin(angle),
 		},
 		color: color.RGBA{
-			R: uint8(200 + rand.Intn(55)),
-			G: uint8(100 + rand.Intn(100)),
-			B: uint8(rand.Intn(100)),
+			R: uint8(200 + rng.Intn(55)),
+			G: uint8(100 + rng.Intn(100)),
+			B: uint8(rng.Intn(100)),
 			A: 255,
 		},
 		life:    config.ParticleLifetime,
@@ -6,64 +6,77 @@ import (
 	assets "go-meteor/src/pkg"
 	"image/color"
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Boss struct {
-	position      systems.Vector
-	velocity      systems.Vector
-	health        int
-	maxHealth     int
-	shootCooldown time.Duration
-	lastShot      time.Time
-	movePattern   int
-	patternTime   float64
-	size          float64
-	sprite        *ebiten.Image
-	bossType      config.BossType
+	position    systems.Vector
+	velocity    systems.Vector
+	health      int
+	maxHealth   int
+	movePattern int
+	patternTime float64
+	size        float64
+	sprite      *ebiten.Image
+	bossType    config.BossType
+	script      *systems.BossScript
+	// patternLength is the pattern clock value at which moves switch.
+	patternLength float64
+	phase         int
+	// invulnerable counts down the ticks after a phase change in which
+	// hits do no damage.
+	invulnerable int
+	// attacks holds the running cooldown of each attack in the phase.
+	attacks       []attackClock
+	cooldownScale float64
 	minions       []*Minion
 	damageFlash   int
-	spawnTime     time.Time
+	fightTicks    int
 	damageTaken   int
 	playerRef     systems.Vector
 	trackingDelay float64
 	direction     float64
 }
 
-func NewBoss(bossType config.BossType) *Boss {
-	var health int
-	var speed float64
-	var shootCooldown time.Duration
-	var size float64
-	var sprite *ebiten.Image
-
-	switch bossType {
-	case config.BossTank:
-		health = config.BossTankHealth
-		speed = config.BossTankSpeed
-		shootCooldown = config.BossTankShootCooldown
-		size = 120
-		sprite = assets.BossTankSprite
-	case config.BossSniper:
-		health = config.BossSniperHealth
-		speed = config.BossSniperSpeed
-		shootCooldown = config.BossSniperShootCooldown
-		size = 90
-		sprite = assets.BossSniperSprite
-	case config.BossSwarm:
-		health = config.BossSwarmHealth
-		speed = config.BossSwarmSpeed
-		shootCooldown = config.BossSwarmShootCooldown
-		size = 100
-		sprite = assets.BossSwarmSprite
-	}
+// attackClock counts ticks towards an attack's next volley, how many
+// volleys of the current burst have fired and how far a spiral has turned.
+type attackClock struct {
+	ticks int
+	fired int
+	spin  float64
+}
+
+// BossScaling multiplies a boss type's base stats. Difficulty presets and
+// Boss Rush both use it to make fights harder without new boss types.
+type BossScaling struct {
+	Health        float64
+	ShootCooldown float64
+}
+
+var bossSprites = map[string]*ebiten.Image{
+	"tank":   assets.BossTankSprite,
+	"sniper": assets.BossSniperSprite,
+	"swarm":  assets.BossSwarmSprite,
+}
+
+// IsBossSprite reports whether name is a sprite a boss script may use.
+func IsBossSprite(name string) bool {
+	_, ok := bossSprites[name]
+	return ok
+}
+
+// NewBoss spawns the boss scripted at index bossType in book.
+func NewBoss(rng *rand.Rand, book *systems.BossBook, bossType config.BossType, scaling BossScaling) *Boss {
+	script := &book.Bosses[bossType]
+	health := max(1, int(float64(script.Health)*scaling.Health))
 
-	startX := float64(config.ScreenWidth) / 4.0
+	startX := config.FieldWidth() / 4.0
 	direction := 1.0
-	if time.Now().UnixNano()%2 == 0 {
-		startX = float64(config.ScreenWidth) * 3.0 / 4.0
+	if rng.Intn(2) == 0 {
+		startX = config.FieldWidth() * 3.0 / 4.0
 		direction = -1.0
 	}
 
@@ -74,31 +87,28 @@ func NewBoss(bossType config.BossType) *Boss {
 		},
 		velocity: systems.Vector{
 			X: 0,
-			Y: speed,
+			Y: script.EntrySpeed,
 		},
 		health:        health,
 		maxHealth:     health,
-		shootCooldown: shootCooldown,
-		lastShot:      time.Now(),
-		movePattern:   int(time.Now().UnixNano() % 4),
+		movePattern:   rng.Intn(len(script.Phases[0].Moves)),
 		patternTime:   0,
-		size:          size,
-		sprite:        sprite,
+		size:          script.Size,
+		sprite:        bossSprites[script.Sprite],
 		bossType:      bossType,
+		script:        script,
+		patternLength: book.PatternLength,
+		attacks:       make([]attackClock, len(script.Phases[0].Attacks)),
+		cooldownScale: scaling.ShootCooldown,
 		damageFlash:   0,
-		spawnTime:     time.Now(),
+		fightTicks:    0,
 		damageTaken:   0,
 		trackingDelay: startX,
 		direction:     direction,
 	}
 
-	minionCount := config.BossMinionCount
-	if bossType == config.BossSwarm {
-		minionCount = config.BossSwarmMinionCount
-	}
-
-	boss.minions = make([]*Minion, minionCount)
-	for i := 0; i < minionCount; i++ {
+	boss.minions = make([]*Minion, script.Minions)
+	for i := 0; i < script.Minions; i++ {
 		angle := float64(i)
 		boss.minions[i] = NewMinion(boss, angle)
 	}
@@ -108,25 +118,26 @@ func NewBoss(bossType config.BossType) *Boss {
 
 func (b *Boss) Update() {
 	b.patternTime += 0.05
+	for i := range b.attacks {
+		b.attacks[i].ticks++
+	}
+	b.fightTicks++
 
 	if b.damageFlash > 0 {
 		b.damageFlash--
 	}
+	if b.invulnerable > 0 {
+		b.invulnerable--
+	}
 
 	if b.position.Y < 100 {
 		b.position.Y += b.velocity.Y
 	} else {
-		switch b.bossType {
-		case config.BossTank:
-			b.updateTankMovement()
-		case config.BossSniper:
-			b.updateSniperMovement()
-		case config.BossSwarm:
-			b.updateSwarmMovement()
-		}
+		moves := b.script.Phases[b.phase].Moves
+		b.move(moves[b.movePattern])
 
-		if b.patternTime > 100 {
-			b.movePattern = (b.movePattern + 1) % 4
+		if b.patternTime > b.patternLength {
+			b.movePattern = (b.movePattern + 1) % len(moves)
 			b.patternTime = 0
 		}
 	}
@@ -134,8 +145,8 @@ func (b *Boss) Update() {
 	if b.position.X < 50 {
 		b.position.X = 50
 	}
-	if b.position.X > config.ScreenWidth-50 {
-		b.position.X = config.ScreenWidth - 50
+	if b.position.X > config.FieldWidth()-50 {
+		b.position.X = config.FieldWidth() - 50
 	}
 
 	for _, minion := range b.minions {
@@ -145,102 +156,155 @@ func (b *Boss) Update() {
 	}
 }
 
-func (b *Boss) updateTankMovement() {
-	switch b.movePattern {
-	case 0:
-		b.position.X += b.direction * 1.5
-		if b.position.X < 50 || b.position.X > config.ScreenWidth-50 {
-			b.direction *= -1
-		}
-	case 1:
-		b.position.X += b.direction * 2
-		if b.position.X < 50 || b.position.X > config.ScreenWidth-50 {
-			b.direction *= -1
+func (b *Boss) move(m systems.BossMove) {
+	switch m.Kind {
+	case systems.MoveBounce:
+		b.position.X += b.direction * m.Speed
+		if m.Wobble != 0 {
+			b.position.X += math.Sin(b.patternTime*m.WobbleFreq) * m.Wobble
 		}
-	case 2:
-		b.position.X += b.direction * 2.5
-		b.position.X += math.Sin(b.patternTime*0.8) * 1.5
-		if b.position.X < 50 || b.position.X > config.ScreenWidth-50 {
+		if b.position.X < 50 || b.position.X > config.FieldWidth()-50 {
 			b.direction *= -1
 		}
-	case 3:
-		if int(b.patternTime*10)%40 < 20 {
-			b.position.X += 2.5
+	case systems.MoveZigzag:
+		if int(b.patternTime*10)%m.Period < m.Period/2 {
+			b.position.X += m.Speed
 		} else {
-			b.position.X -= 2.5
+			b.position.X -= m.Speed
 		}
-	}
-}
-
-func (b *Boss) updateSniperMovement() {
-	switch b.movePattern {
-	case 0:
-		b.position.X += b.direction * 3
-		b.position.X += math.Sin(b.patternTime*1.5) * 1.5
-		if b.position.X < 50 || b.position.X > config.ScreenWidth-50 {
-			b.direction *= -1
-		}
-	case 1:
-		b.position.X += b.direction * 5
-		if b.position.X < 50 || b.position.X > config.ScreenWidth-50 {
-			b.direction *= -1
-		}
-	case 2:
-		b.trackingDelay = b.trackingDelay*0.95 + b.playerRef.X*0.05
+	case systems.MoveTrack:
+		b.trackingDelay = b.trackingDelay*(1-m.Follow) + b.playerRef.X*m.Follow
 		targetX := b.trackingDelay
-		if b.position.X < targetX-5 {
-			b.position.X += 4
-		} else if b.position.X > targetX+5 {
-			b.position.X -= 4
+		if b.position.X < targetX-m.Deadzone {
+			b.position.X += m.Speed
+		} else if b.position.X > targetX+m.Deadzone {
+			b.position.X -= m.Speed
 		}
-	case 3:
-		radius := 300.0
-		centerX := float64(config.ScreenWidth / 2)
-		offset := math.Cos(b.patternTime*0.8) * radius
-		if centerX+offset < 60 {
-			offset = 60 - centerX
-		} else if centerX+offset > config.ScreenWidth-60 {
-			offset = config.ScreenWidth - 60 - centerX
+	case systems.MoveSweep:
+		centerX := config.FieldWidth() / 2
+		offset := math.Cos(b.patternTime*m.Freq) * m.Radius
+		if centerX+offset < m.Margin {
+			offset = m.Margin - centerX
+		} else if centerX+offset > config.FieldWidth()-m.Margin {
+			offset = config.FieldWidth() - m.Margin - centerX
 		}
 		b.position.X = centerX + offset
 	}
 }
 
-func (b *Boss) updateSwarmMovement() {
-	switch b.movePattern {
-	case 0:
-		b.position.X += b.direction * 2.5
-		b.position.X += math.Sin(b.patternTime*0.9) * 1
-		if b.position.X < 50 || b.position.X > config.ScreenWidth-50 {
-			b.direction *= -1
+// Fire returns the volleys due this tick and restarts their cooldowns.
+// Bosses hold fire until they have come down into the field.
+func (b *Boss) Fire() []BossShot {
+	if b.position.Y < config.BossShootMinY {
+		return nil
+	}
+
+	var shots []BossShot
+	for i, a := range b.script.Phases[b.phase].Attacks {
+		clock := &b.attacks[i]
+		wait := a.CooldownMs
+		if clock.fired > 0 {
+			wait = a.BurstGapMs
 		}
-	case 1:
-		radius := 280.0
-		centerX := float64(config.ScreenWidth / 2)
-		offset := math.Cos(b.patternTime*0.7) * radius
-		if centerX+offset < 70 {
-			offset = 70 - centerX
-		} else if centerX+offset > config.ScreenWidth-70 {
-			offset = config.ScreenWidth - 70 - centerX
+		if clock.ticks < b.cooldownTicks(wait) {
+			continue
 		}
-		b.position.X = centerX + offset
-	case 2:
-		b.trackingDelay = b.trackingDelay*0.9 + b.playerRef.X*0.1
-		targetX := b.trackingDelay
-		if b.position.X < targetX-10 {
-			b.position.X += 3
-		} else if b.position.X > targetX+10 {
-			b.position.X -= 3
+
+		shots = b.fireAttack(shots, a, clock, b.position, b.playerRef)
+	}
+	return shots
+}
+
+// fireAttack appends a volley of a from origin to shots and moves its
+// clock on to the next.
+func (b *Boss) fireAttack(shots []BossShot, a systems.BossAttack, clock *attackClock, origin, target systems.Vector) []BossShot {
+	shots = b.volley(shots, a, origin, target, clock.spin)
+	clock.ticks = 0
+	clock.fired = (clock.fired + 1) % a.Volleys()
+	clock.spin = math.Mod(clock.spin+a.SpinDeg*math.Pi/180, 2*math.Pi)
+	return shots
+}
+
+func (b *Boss) cooldownTicks(ms int) int {
+	cooldown := time.Duration(ms) * time.Millisecond
+	return systems.TicksFor(time.Duration(float64(cooldown) * b.cooldownScale))
+}
+
+// volley appends one volley of a from origin to shots, turned spin from
+// where it would point. Angles are measured from straight down.
+func (b *Boss) volley(shots []BossShot, a systems.BossAttack, origin, target systems.Vector, spin float64) []BossShot {
+	centre := (float64(a.Count) - 1) / 2
+	y := origin.Y + a.OffsetY
+
+	aim := spin
+	if a.Aimed {
+		aim += math.Atan2(target.X-origin.X, target.Y-y)
+	}
+	spread := a.SpreadDeg * math.Pi / 180
+
+	for i := 0; i < a.Count; i++ {
+		angle := aim
+		if a.Count > 1 {
+			angle += spread * (float64(i)/float64(a.Count-1) - 0.5)
 		}
-	case 3:
-		if int(b.patternTime*10)%30 < 15 {
-			b.position.X += 4
-		} else {
-			b.position.X -= 4
+		shots = append(shots, BossShot{
+			X:        origin.X + (float64(i)-centre)*a.SpacingX,
+			Y:        y,
+			VX:       math.Sin(angle) * a.Speed,
+			VY:       math.Cos(angle) * a.Speed,
+			Accel:    a.Accel,
+			MaxSpeed: a.MaxSpeed,
+			Turn:     a.TurnDeg * math.Pi / 180,
+			Bounces:  a.Bounces,
+			Life:     systems.TicksFor(time.Duration(a.LifetimeMs) * time.Millisecond),
+			Sprite:   ProjectileSpriteNamed(a.Sprite),
+		})
+	}
+	return shots
+}
+
+// enterPhase moves on to the deepest phase the boss's health has reached.
+// Each phase starts its moves and attacks afresh, behind a short window of
+// invulnerability.
+func (b *Boss) enterPhase() {
+	phases := b.script.Phases
+	next := b.phase
+	for next+1 < len(phases) && float64(b.health) <= phases[next+1].FromHealth*float64(b.maxHealth) {
+		next++
+	}
+	if next == b.phase {
+		return
+	}
+	b.phase = next
+	b.movePattern = 0
+	b.patternTime = 0
+	b.attacks = make([]attackClock, len(phases[next].Attacks))
+	b.invulnerable = systems.TicksFor(config.BossPhaseInvulnerability)
+
+	if phases[next].RespawnMinions {
+		for i, m := range b.minions {
+			if m == nil {
+				b.minions[i] = NewMinion(b, float64(i))
+			}
 		}
 	}
 }
 
+// Phase is the index of the phase the boss is in.
+func (b *Boss) Phase() int {
+	return b.phase
+}
+
+// PhaseMarks are the health fractions at which later phases start, for
+// the boss bar.
+func (b *Boss) PhaseMarks() []float64 {
+	return b.script.PhaseMarks()
+}
+
+func (b *Boss) IsInvulnerable() bool {
+	return b.invulnerable > 0
+}
+
 func (b *Boss) Draw(screen *ebiten.Image) {
 	for _, minion := range b.minions {
 		if minion != nil {
@@ -257,7 +321,13 @@ func (b *Boss) Draw(screen *ebiten.Image) {
 	op.GeoM.Scale(scale, scale)
 	op.GeoM.Translate(b.position.X, b.position.Y)
 
-	if b.damageFlash > 0 {
+	if b.invulnerable > 0 {
+		if (b.invulnerable/4)%2 == 0 {
+			op.ColorScale.ScaleWithColor(color.RGBA{255, 220, 120, 255})
+		} else {
+			op.ColorScale.ScaleAlpha(0.5)
+		}
+	} else if b.damageFlash > 0 {
 		op.ColorScale.ScaleWithColor(color.RGBA{255, 100, 100, 255})
 	}
 
@@ -273,22 +343,26 @@ func (b *Boss) Collider() systems.Rect {
 	}
 }
 
-func (b *Boss) GetPosition() systems.Vector {
-	return b.position
-}
-
-func (b *Boss) CanShoot() bool {
-	return time.Since(b.lastShot) >= b.shootCooldown
+// Hitbox is a disc inside the sprite, which is drawn size*2 wide around
+// position.
+func (b *Boss) Hitbox() systems.Shape {
+	return systems.Circle{Center: b.position, Radius: b.size * 0.8}
 }
 
-func (b *Boss) Shoot() {
-	b.lastShot = time.Now()
+func (b *Boss) GetPosition() systems.Vector {
+	return b.position
 }
 
+// TakeDamage reports whether the hit killed the boss. Hits while it is
+// invulnerable do nothing.
 func (b *Boss) TakeDamage(damage int) bool {
+	if b.invulnerable > 0 {
+		return false
+	}
 	b.health -= damage
 	b.damageTaken += damage
 	b.damageFlash = 10
+	b.enterPhase()
 	return b.health <= 0
 }
 
@@ -300,10 +374,6 @@ func (b *Boss) GetMaxHealth() int {
 	return b.maxHealth
 }
 
-func (b *Boss) GetBossType() config.BossType {
-	return b.bossType
-}
-
 func (b *Boss) GetMinions() []*Minion {
 	if b == nil {
 		return nil
@@ -324,8 +394,10 @@ func (b *Boss) SetPlayerPosition(pos systems.Vector) {
 	b.playerRef = pos
 }
 
-func (b *Boss) GetSpawnTime() time.Time {
-	return b.spawnTime
+// FightDuration is how long the boss has been on screen, measured in
+// update ticks so that it does not depend on frame pacing.
+func (b *Boss) FightDuration() time.Duration {
+	return time.Duration(b.fightTicks) * time.Second / time.Duration(ebiten.TPS())
 }
 
 func (b *Boss) GetDamageTaken() int {
@@ -333,5 +405,5 @@ func (b *Boss) GetDamageTaken() int {
 }
 
 func (b *Boss) IsOutOfScreen() bool {
-	return b.position.Y > config.ScreenHeight+100
+	return b.position.Y > config.FieldHeight()+100
 }
@@ -4,44 +4,200 @@ import (
 	"go-meteor/internal/config"
 	"go-meteor/internal/systems"
 	"image/color"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
+// ProjectileSprite is how a boss or minion projectile is drawn.
+type ProjectileSprite int
+
+const (
+	SpriteOrb ProjectileSprite = iota
+	SpriteNeedle
+	SpriteMissile
+	SpritePellet
+)
+
+var projectileSprites = map[string]ProjectileSprite{
+	"orb":     SpriteOrb,
+	"needle":  SpriteNeedle,
+	"missile": SpriteMissile,
+	"pellet":  SpritePellet,
+}
+
+// projectileSizes are the collider radius of each sprite.
+var projectileSizes = map[ProjectileSprite]float64{
+	SpriteOrb:     10,
+	SpriteNeedle:  5,
+	SpriteMissile: 8,
+	SpritePellet:  7,
+}
+
+// IsProjectileSprite reports whether name is a sprite a boss attack may
+// use.
+func IsProjectileSprite(name string) bool {
+	_, ok := projectileSprites[name]
+	return ok
+}
+
+// ProjectileSpriteNamed returns the sprite called name, or the orb when
+// name is empty.
+func ProjectileSpriteNamed(name string) ProjectileSprite {
+	return projectileSprites[name]
+}
+
+// BossShot is one projectile a boss or minion fires: its spawn point,
+// velocity and how it flies from there.
+type BossShot struct {
+	X, Y   float64
+	VX, VY float64
+	// Accel is added to the speed every tick, keeping it at least 1 and at
+	// most MaxSpeed when that is set.
+	Accel    float64
+	MaxSpeed float64
+	// Turn is how far a homing shot may steer a tick, in radians.
+	Turn    float64
+	Bounces int
+	// Life is the ticks before the shot fizzles out, or 0 to fly until it
+	// leaves the field.
+	Life   int
+	Sprite ProjectileSprite
+}
+
 type BossProjectile struct {
 	position systems.Vector
 	velocity systems.Vector
 	size     float64
+	accel    float64
+	maxSpeed float64
+	turn     float64
+	bounces  int
+	life     int
+	age      int
+	sprite   ProjectileSprite
+	target   systems.Vector
 }
 
 func NewBossProjectile(x, y float64) *BossProjectile {
-	return &BossProjectile{
-		position: systems.Vector{X: x, Y: y},
-		velocity: systems.Vector{X: 0, Y: 5.0},
-		size:     10,
-	}
+	bp := &BossProjectile{}
+	bp.Reset(x, y)
+	return bp
 }
 
 func (bp *BossProjectile) Reset(x, y float64) {
-	bp.position.X = x
-	bp.position.Y = y
-	bp.velocity.X = 0
-	bp.velocity.Y = 5.0
-	bp.size = 10
+	bp.Launch(BossShot{X: x, Y: y, VY: 5.0})
+}
+
+// Launch reuses the projectile for shot.
+func (bp *BossProjectile) Launch(shot BossShot) {
+	bp.position = systems.Vector{X: shot.X, Y: shot.Y}
+	bp.velocity = systems.Vector{X: shot.VX, Y: shot.VY}
+	bp.size = projectileSizes[shot.Sprite]
+	bp.accel = shot.Accel
+	bp.maxSpeed = shot.MaxSpeed
+	bp.turn = shot.Turn
+	bp.bounces = shot.Bounces
+	bp.life = shot.Life
+	bp.age = 0
+	bp.sprite = shot.Sprite
+	bp.target = systems.Vector{X: shot.X, Y: shot.Y + 1}
+}
+
+func (bp *BossProjectile) IsHoming() bool {
+	return bp.turn > 0
+}
+
+// SetTarget is where a homing projectile steers.
+func (bp *BossProjectile) SetTarget(target systems.Vector) {
+	bp.target = target
 }
 
 func (bp *BossProjectile) Update() {
+	bp.age++
+	if bp.turn > 0 {
+		bp.steer()
+	}
+	if bp.accel != 0 {
+		bp.accelerate()
+	}
+
 	bp.position.X += bp.velocity.X
 	bp.position.Y += bp.velocity.Y
+
+	if bp.bounces > 0 {
+		if (bp.position.X < bp.size && bp.velocity.X < 0) ||
+			(bp.position.X > config.FieldWidth()-bp.size && bp.velocity.X > 0) {
+			bp.velocity.X = -bp.velocity.X
+			bp.bounces--
+		}
+	}
+}
+
+// steer turns the velocity towards the target by at most the turn rate.
+func (bp *BossProjectile) steer() {
+	heading := math.Atan2(bp.velocity.Y, bp.velocity.X)
+	want := math.Atan2(bp.target.Y-bp.position.Y, bp.target.X-bp.position.X)
+	diff := math.Remainder(want-heading, 2*math.Pi)
+	diff = max(-bp.turn, min(bp.turn, diff))
+
+	speed := math.Hypot(bp.velocity.X, bp.velocity.Y)
+	heading += diff
+	bp.velocity.X = math.Cos(heading) * speed
+	bp.velocity.Y = math.Sin(heading) * speed
+}
+
+func (bp *BossProjectile) accelerate() {
+	speed := math.Hypot(bp.velocity.X, bp.velocity.Y)
+	if speed == 0 {
+		return
+	}
+	next := max(1, speed+bp.accel)
+	if bp.maxSpeed > 0 {
+		next = min(next, bp.maxSpeed)
+	}
+	bp.velocity.X *= next / speed
+	bp.velocity.Y *= next / speed
+}
+
+// IsExpired reports whether the projectile's lifetime has run out.
+func (bp *BossProjectile) IsExpired() bool {
+	return bp.life > 0 && bp.age >= bp.life
 }
 
 func (bp *BossProjectile) Draw(screen *ebiten.Image) {
-	projectileColor := color.RGBA{255, 50, 50, 255}
-	vector.DrawFilledCircle(screen, float32(bp.position.X), float32(bp.position.Y), float32(bp.size), projectileColor, false)
+	x, y := float32(bp.position.X), float32(bp.position.Y)
+
+	switch bp.sprite {
+	case SpriteNeedle:
+		dx, dy := bp.direction(14)
+		vector.StrokeLine(screen, x-dx, y-dy, x+dx, y+dy, 8, color.RGBA{120, 220, 255, 90}, false)
+		vector.StrokeLine(screen, x-dx, y-dy, x+dx, y+dy, 3, color.RGBA{230, 250, 255, 255}, false)
+	case SpriteMissile:
+		dx, dy := bp.direction(16)
+		vector.StrokeLine(screen, x, y, x-dx, y-dy, 4, color.RGBA{255, 200, 80, 120}, false)
+		vector.DrawFilledCircle(screen, x, y, float32(bp.size+2), color.RGBA{255, 140, 40, 100}, false)
+		vector.DrawFilledCircle(screen, x, y, float32(bp.size), color.RGBA{255, 120, 30, 255}, false)
+	case SpritePellet:
+		vector.DrawFilledCircle(screen, x, y, float32(bp.size+2), color.RGBA{200, 100, 255, 100}, false)
+		vector.DrawFilledCircle(screen, x, y, float32(bp.size), color.RGBA{170, 60, 230, 255}, false)
+	default:
+		projectileColor := color.RGBA{255, 50, 50, 255}
+		vector.DrawFilledCircle(screen, x, y, float32(bp.size), projectileColor, false)
+
+		glowColor := color.RGBA{255, 100, 100, 100}
+		vector.DrawFilledCircle(screen, x, y, float32(bp.size+3), glowColor, false)
+	}
+}
 
-	glowColor := color.RGBA{255, 100, 100, 100}
-	vector.DrawFilledCircle(screen, float32(bp.position.X), float32(bp.position.Y), float32(bp.size+3), glowColor, false)
+// direction is a vector of length n along the projectile's flight.
+func (bp *BossProjectile) direction(n float64) (float32, float32) {
+	speed := math.Hypot(bp.velocity.X, bp.velocity.Y)
+	if speed == 0 {
+		return 0, float32(n)
+	}
+	return float32(bp.velocity.X / speed * n), float32(bp.velocity.Y / speed * n)
 }
 
 func (bp *BossProjectile) Collider() systems.Rect {
@@ -53,11 +209,24 @@ func (bp *BossProjectile) Collider() systems.Rect {
 	}
 }
 
+// Hitbox is the drawn disc around position, or the length of a needle.
+func (bp *BossProjectile) Hitbox() systems.Shape {
+	if bp.sprite == SpriteNeedle {
+		dx, dy := bp.direction(14)
+		return systems.Capsule{
+			A:      systems.Vector{X: bp.position.X - float64(dx), Y: bp.position.Y - float64(dy)},
+			B:      systems.Vector{X: bp.position.X + float64(dx), Y: bp.position.Y + float64(dy)},
+			Radius: bp.size,
+		}
+	}
+	return systems.Circle{Center: bp.position, Radius: bp.size}
+}
+
 func (bp *BossProjectile) IsOutOfScreen() bool {
-	return bp.position.Y > config.ScreenHeight+50 ||
+	return bp.position.Y > config.FieldHeight()+50 ||
 		bp.position.Y < -50 ||
 		bp.position.X < -50 ||
-		bp.position.X > config.ScreenWidth+50
+		bp.position.X > config.FieldWidth()+50
 }
 
 func (bp *BossProjectile) GetPosition() systems.Vector {
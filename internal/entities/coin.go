@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -80,6 +81,23 @@ func (c *Coin) Collect(targetX, targetY float64) {
 	c.targetY = targetY
 }
 
+// Attract pulls a falling coin up to step pixels toward (x, y), which is
+// where its center should end up.
+func (c *Coin) Attract(x, y, step float64) {
+	if c.collected {
+		return
+	}
+	dx := x - (c.position.X + CoinSize/2)
+	dy := y - (c.position.Y + CoinSize/2)
+	dist := math.Hypot(dx, dy)
+	if dist == 0 {
+		return
+	}
+	step = math.Min(step, dist)
+	c.position.X += dx / dist * step
+	c.position.Y += dy / dist * step
+}
+
 func (c *Coin) IsCollected() bool {
 	return c.collected
 }
@@ -103,7 +121,7 @@ func (c *Coin) Draw(screen *ebiten.Image) {
 }
 
 func (c *Coin) IsOffScreen() bool {
-	return c.position.Y > config.ScreenHeight+50
+	return c.position.Y > config.FieldHeight()+50
 }
 
 func (c *Coin) GetBounds() (float64, float64, float64, float64) {
@@ -114,6 +132,6 @@ func (c *Coin) GetValue() int {
 	return c.value
 }
 
-func ShouldDropCoin() bool {
-	return rand.Float64() < CoinDropChance
+func ShouldDropCoin(rng *rand.Rand) bool {
+	return rng.Float64() < CoinDropChance
 }
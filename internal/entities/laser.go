@@ -17,6 +17,9 @@ type Laser struct {
 	isSuperPower  bool
 	isLaserBeam   bool
 	damage        int
+	owner         int
+	// hitbox backs Hitbox, so the collision passes don't allocate.
+	hitbox systems.Capsule
 }
 
 func NewLaser(pos systems.Vector, isSuperPower bool, isLaserBeam bool) *Laser {
@@ -88,6 +91,20 @@ func (l *Laser) Collider() systems.Rect {
 	)
 }
 
+// Hitbox runs down the middle of the bolt, as wide as it is.
+func (l *Laser) Hitbox() systems.Shape {
+	c := l.Collider()
+	r := c.Width / 2
+	top := c.Y + min(r, c.Height/2)
+	bottom := max(top, c.MaxY()-r)
+	l.hitbox = systems.Capsule{
+		A:      systems.Vector{X: c.CenterX(), Y: top},
+		B:      systems.Vector{X: c.CenterX(), Y: bottom},
+		Radius: r,
+	}
+	return &l.hitbox
+}
+
 func (l *Laser) IsLaserBeam() bool {
 	return l.isLaserBeam
 }
@@ -99,3 +116,12 @@ func (l *Laser) GetDamage() int {
 func (l *Laser) IsOutOfScreen() bool {
 	return l.position.Y < -100
 }
+
+// Owner is the slot of the ship that fired the laser.
+func (l *Laser) Owner() int {
+	return l.owner
+}
+
+func (l *Laser) SetOwner(slot int) {
+	l.owner = slot
+}
@@ -23,24 +23,25 @@ type Meteor struct {
 	movement      systems.Vector
 	rotationSpeed float64
 	sprite        *ebiten.Image
+	spriteIndex   int
 	meteorType    MeteorType
+
+	// hitbox is the outline for hitboxBox and hitboxTurn, kept so the
+	// collision passes of a tick build it once.
+	hitbox     systems.Polygon
+	hitboxBox  systems.Rect
+	hitboxTurn float64
 }
 
-func NewMeteor(speedMultiplier float64) *Meteor {
+func NewMeteor(rng *rand.Rand, speedMultiplier float64, mix systems.MeteorMix) *Meteor {
 	pos := systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -100,
 	}
 
-	meteorType := MeteorNormal
-	roll := rand.Float64()
-	if roll < config.MeteorIceSpawnChance {
-		meteorType = MeteorIce
-	} else if roll < config.MeteorIceSpawnChance+config.MeteorExplosiveSpawnChance {
-		meteorType = MeteorExplosive
-	}
+	meteorType := rollMeteorType(rng, mix)
 
-	velocity := config.MeteorMinSpeed + rand.Float64()*(config.MeteorMaxSpeed-config.MeteorMinSpeed)
+	velocity := config.MeteorMinSpeed + rng.Float64()*(config.MeteorMaxSpeed-config.MeteorMinSpeed)
 
 	if meteorType == MeteorExplosive {
 		velocity = config.MeteorExplosiveSpeed
@@ -53,33 +54,29 @@ func NewMeteor(speedMultiplier float64) *Meteor {
 		Y: velocity,
 	}
 
-	sprite := assets.MeteorSprites[rand.Intn(len(assets.MeteorSprites))]
+	rotationSpeed := config.MeteorRotationMin + rng.Float64()*(config.MeteorRotationMax-config.MeteorRotationMin)
+	spriteIndex := rng.Intn(len(assets.MeteorSprites))
 
 	m := &Meteor{
 		position:      pos,
 		movement:      movement,
-		rotationSpeed: config.MeteorRotationMin + rand.Float64()*(config.MeteorRotationMax-config.MeteorRotationMin),
-		sprite:        sprite,
+		rotationSpeed: rotationSpeed,
+		sprite:        assets.MeteorSprites[spriteIndex],
+		spriteIndex:   spriteIndex,
 		meteorType:    meteorType,
 	}
 	return m
 }
 
-func (m *Meteor) Reset(speedMultiplier float64) {
+func (m *Meteor) Reset(rng *rand.Rand, speedMultiplier float64, mix systems.MeteorMix) {
 	m.position = systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -100,
 	}
 
-	m.meteorType = MeteorNormal
-	roll := rand.Float64()
-	if roll < config.MeteorIceSpawnChance {
-		m.meteorType = MeteorIce
-	} else if roll < config.MeteorIceSpawnChance+config.MeteorExplosiveSpawnChance {
-		m.meteorType = MeteorExplosive
-	}
+	m.meteorType = rollMeteorType(rng, mix)
 
-	velocity := config.MeteorMinSpeed + rand.Float64()*(config.MeteorMaxSpeed-config.MeteorMinSpeed)
+	velocity := config.MeteorMinSpeed + rng.Float64()*(config.MeteorMaxSpeed-config.MeteorMinSpeed)
 
 	if m.meteorType == MeteorExplosive {
 		velocity = config.MeteorExplosiveSpeed
@@ -93,8 +90,19 @@ func (m *Meteor) Reset(speedMultiplier float64) {
 	}
 
 	m.rotation = 0
-	m.rotationSpeed = config.MeteorRotationMin + rand.Float64()*(config.MeteorRotationMax-config.MeteorRotationMin)
-	m.sprite = assets.MeteorSprites[rand.Intn(len(assets.MeteorSprites))]
+	m.rotationSpeed = config.MeteorRotationMin + rng.Float64()*(config.MeteorRotationMax-config.MeteorRotationMin)
+	m.spriteIndex = rng.Intn(len(assets.MeteorSprites))
+	m.sprite = assets.MeteorSprites[m.spriteIndex]
+}
+
+func rollMeteorType(rng *rand.Rand, mix systems.MeteorMix) MeteorType {
+	roll := rng.Float64()
+	if roll < mix.Ice {
+		return MeteorIce
+	} else if roll < mix.Ice+mix.Explosive {
+		return MeteorExplosive
+	}
+	return MeteorNormal
 }
 
 func (m *Meteor) Update() {
@@ -144,8 +152,32 @@ func (m *Meteor) Collider() systems.Rect {
 	)
 }
 
+// meteorOutline is an octagon inside the meteor sprites, which are roughly
+// round rocks.
+var meteorOutline = []systems.Vector{
+	{X: 0.3, Y: 0.05},
+	{X: 0.7, Y: 0.05},
+	{X: 0.95, Y: 0.3},
+	{X: 0.95, Y: 0.7},
+	{X: 0.7, Y: 0.95},
+	{X: 0.3, Y: 0.95},
+	{X: 0.05, Y: 0.7},
+	{X: 0.05, Y: 0.3},
+}
+
+// Hitbox is the meteor's outline, turned with the sprite. It is rebuilt
+// only once the meteor has moved or turned, and changes with it.
+func (m *Meteor) Hitbox() systems.Shape {
+	box := m.Collider()
+	if m.hitbox.Points == nil || box != m.hitboxBox || m.rotation != m.hitboxTurn {
+		m.hitbox.Fit(box, m.rotation, meteorOutline...)
+		m.hitboxBox, m.hitboxTurn = box, m.rotation
+	}
+	return &m.hitbox
+}
+
 func (m *Meteor) IsOutOfScreen() bool {
-	return m.position.Y > config.ScreenHeight+100
+	return m.position.Y > config.FieldHeight()+100
 }
 
 func (m *Meteor) GetPosition() systems.Vector {
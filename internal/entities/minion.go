@@ -11,17 +11,19 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
+const minionSpeed = 1.2
+
 type Minion struct {
-	position      systems.Vector
-	velocity      systems.Vector
-	health        int
-	size          float64
-	parentBoss    *Boss
-	shootCooldown int
-	targetPlayer  systems.Vector
-	speed         float64
-	side          float64
-	offsetX       float64
+	position     systems.Vector
+	velocity     systems.Vector
+	health       int
+	size         float64
+	parentBoss   *Boss
+	attack       attackClock
+	targetPlayer systems.Vector
+	speed        float64
+	side         float64
+	offsetX      float64
 }
 
 func NewMinion(boss *Boss, offsetAngle float64) *Minion {
@@ -39,15 +41,14 @@ func NewMinion(boss *Boss, offsetAngle float64) *Minion {
 	spawnY := boss.position.Y
 
 	return &Minion{
-		position:      systems.Vector{X: spawnX, Y: spawnY},
-		velocity:      systems.Vector{X: 0, Y: 0},
-		health:        config.BossMinionHealth,
-		size:          config.BossMinionSize,
-		parentBoss:    boss,
-		shootCooldown: 0,
-		speed:         1.2,
-		side:          side,
-		offsetX:       offsetX,
+		position:   systems.Vector{X: spawnX, Y: spawnY},
+		velocity:   systems.Vector{X: 0, Y: 0},
+		health:     config.BossMinionHealth,
+		size:       config.BossMinionSize,
+		parentBoss: boss,
+		speed:      minionSpeed,
+		side:       side,
+		offsetX:    offsetX,
 	}
 }
 
@@ -77,17 +78,15 @@ func (m *Minion) Update() {
 	if m.position.X < 20 {
 		m.position.X = 20
 	}
-	if m.position.X > config.ScreenWidth-20 {
-		m.position.X = config.ScreenWidth - 20
+	if m.position.X > config.FieldWidth()-20 {
+		m.position.X = config.FieldWidth() - 20
 	}
 
 	if m.position.Y < m.parentBoss.position.Y-20 {
 		m.position.Y = m.parentBoss.position.Y - 20
 	}
 
-	if m.shootCooldown > 0 {
-		m.shootCooldown--
-	}
+	m.attack.ticks++
 }
 
 func (m *Minion) Draw(screen *ebiten.Image) {
@@ -104,6 +103,11 @@ func (m *Minion) Collider() systems.Rect {
 	}
 }
 
+// Hitbox is the drawn disc; position is its centre.
+func (m *Minion) Hitbox() systems.Shape {
+	return systems.Circle{Center: m.position, Radius: m.size}
+}
+
 func (m *Minion) GetPosition() systems.Vector {
 	return m.position
 }
@@ -121,10 +125,19 @@ func (m *Minion) SetTarget(target systems.Vector) {
 	m.targetPlayer = target
 }
 
-func (m *Minion) CanShoot() bool {
-	return m.shootCooldown <= 0
-}
-
-func (m *Minion) Shoot() {
-	m.shootCooldown = 90
+// Fire returns the minion's volley when its boss's minion attack is due.
+func (m *Minion) Fire() []BossShot {
+	if m.parentBoss == nil {
+		return nil
+	}
+	b := m.parentBoss
+	a := b.script.MinionAttack
+	wait := a.CooldownMs
+	if m.attack.fired > 0 {
+		wait = a.BurstGapMs
+	}
+	if m.attack.ticks < b.cooldownTicks(wait) {
+		return nil
+	}
+	return b.fireAttack(nil, a, &m.attack, m.position, m.targetPlayer)
 }
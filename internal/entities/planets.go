@@ -17,9 +17,9 @@ type Planet struct {
 	sprite        *ebiten.Image
 }
 
-func NewPlanet() *Planet {
+func NewPlanet(rng *rand.Rand) *Planet {
 	pos := systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -500,
 	}
 
@@ -30,7 +30,7 @@ func NewPlanet() *Planet {
 		Y: velocity,
 	}
 
-	sprite := assets.PlanetsSprites[rand.Intn(len(assets.PlanetsSprites))]
+	sprite := assets.PlanetsSprites[rng.Intn(len(assets.PlanetsSprites))]
 
 	m := &Planet{
 		position: pos,
@@ -41,7 +41,7 @@ func NewPlanet() *Planet {
 }
 
 func (m *Planet) IsOutOfScreen() bool {
-	return m.position.Y > config.ScreenHeight+500
+	return m.position.Y > config.FieldHeight()+500
 }
 
 func (m *Planet) Update() {
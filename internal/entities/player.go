@@ -6,6 +6,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"go-meteor/internal/config"
+	"go-meteor/internal/input"
 	"go-meteor/internal/systems"
 	assets "go-meteor/src/pkg"
 )
@@ -14,7 +15,7 @@ type GameInterface interface {
 	AddLaser(laser *Laser)
 	GetSuperPowerActive() bool
 	GetLaserBeamActive() bool
-	ResetCombo()
+	ResetCombo(slot int)
 }
 
 type Player struct {
@@ -31,23 +32,16 @@ type Player struct {
 	hasShield          bool
 	isSlowed           bool
 	lives              int
+	baseLives          int
+	// slot is 0 for player one and 1 for the co-op partner; lasers carry
+	// it so kills are credited to the ship that fired.
+	slot int
 }
 
 func NewPlayer(game GameInterface) *Player {
-	sprite := assets.PlayerSprite
-
-	bounds := sprite.Bounds()
-	halfW := float64(bounds.Dx()) / 2
-
-	pos := systems.Vector{
-		X: (config.ScreenWidth / 2) - halfW,
-		Y: (config.ScreenHeight) - 170,
-	}
-
-	return &Player{
+	p := &Player{
 		game:               game,
-		position:           pos,
-		sprite:             sprite,
+		sprite:             assets.PlayerSprite,
 		shootCooldown:      systems.NewTimer(config.PlayerShootCooldown),
 		invincibilityTimer: systems.NewTimer(config.InvincibilityTime),
 		shieldTimer:        systems.NewTimer(config.ShieldTime),
@@ -56,7 +50,45 @@ func NewPlayer(game GameInterface) *Player {
 		hasShield:          false,
 		isSlowed:           false,
 		lives:              config.InitialLives,
+		baseLives:          config.InitialLives,
 	}
+	p.MoveToStart()
+	return p
+}
+
+// MoveToStart puts the ship at its starting place near the bottom middle
+// of the current field. The place is the same whatever the skin.
+func (p *Player) MoveToStart() {
+	halfW := float64(assets.PlayerSprite.Bounds().Dx()) / 2
+	p.position = systems.Vector{
+		X: (config.FieldWidth() / 2) - halfW,
+		Y: config.FieldHeight() - 170,
+	}
+}
+
+// SetBaseLives sets the lives a run starts with; hearts can be healed back
+// up to it and extra lives stack on top.
+func (p *Player) SetBaseLives(lives int) {
+	p.baseLives = lives
+	p.lives = lives
+}
+
+func (p *Player) GetBaseLives() int {
+	return p.baseLives
+}
+
+func (p *Player) SetSlot(slot int) {
+	p.slot = slot
+}
+
+func (p *Player) Slot() int {
+	return p.slot
+}
+
+// SetCenterX moves the ship so its middle is at x, used to spread co-op
+// ships apart at the start of a run.
+func (p *Player) SetCenterX(x float64) {
+	p.position.X = x - float64(p.sprite.Bounds().Dx())/2
 }
 
 func (p *Player) SetSkin(skinID string) {
@@ -65,8 +97,11 @@ func (p *Player) SetSkin(skinID string) {
 	}
 }
 
-func (p *Player) MoveLeft() {
-	speed := config.PlayerSpeed
+// The Move functions move by scale times the ship's speed; analog sticks
+// pass less than 1.
+
+func (p *Player) MoveLeft(scale float64) {
+	speed := config.PlayerSpeed * scale
 	if p.isSlowed {
 		speed *= config.MeteorIceSlowFactor
 	}
@@ -76,21 +111,21 @@ func (p *Player) MoveLeft() {
 	}
 }
 
-func (p *Player) MoveRight() {
-	speed := config.PlayerSpeed
+func (p *Player) MoveRight(scale float64) {
+	speed := config.PlayerSpeed * scale
 	if p.isSlowed {
 		speed *= config.MeteorIceSlowFactor
 	}
 	p.position.X += speed
 	bounds := p.sprite.Bounds()
-	maxX := float64(config.ScreenWidth) - float64(bounds.Dx())
+	maxX := config.FieldWidth() - float64(bounds.Dx())
 	if p.position.X > maxX {
 		p.position.X = maxX
 	}
 }
 
-func (p *Player) MoveUp() {
-	speed := config.PlayerSpeed
+func (p *Player) MoveUp(scale float64) {
+	speed := config.PlayerSpeed * scale
 	if p.isSlowed {
 		speed *= config.MeteorIceSlowFactor
 	}
@@ -100,14 +135,14 @@ func (p *Player) MoveUp() {
 	}
 }
 
-func (p *Player) MoveDown() {
-	speed := config.PlayerSpeed
+func (p *Player) MoveDown(scale float64) {
+	speed := config.PlayerSpeed * scale
 	if p.isSlowed {
 		speed *= config.MeteorIceSlowFactor
 	}
 	p.position.Y += speed
 	bounds := p.sprite.Bounds()
-	maxY := float64(config.ScreenHeight) - float64(bounds.Dy())
+	maxY := config.FieldHeight() - float64(bounds.Dy())
 	if p.position.Y > maxY {
 		p.position.Y = maxY
 	}
@@ -132,6 +167,7 @@ func (p *Player) Shoot() {
 	superPowerActive := p.game.GetSuperPowerActive()
 	laserBeamActive := p.game.GetLaserBeamActive()
 	bullet := NewLaser(spawnPos, superPowerActive, laserBeamActive)
+	bullet.SetOwner(p.slot)
 	p.game.AddLaser(bullet)
 
 	if superPowerActive {
@@ -146,27 +182,29 @@ func (p *Player) Shoot() {
 
 		bulletLeft := NewLaser(spawnLeftPos, true, laserBeamActive)
 		bulletRight := NewLaser(spawnRightPos, true, laserBeamActive)
+		bulletLeft.SetOwner(p.slot)
+		bulletRight.SetOwner(p.slot)
 		p.game.AddLaser(bulletLeft)
 		p.game.AddLaser(bulletRight)
 	}
 
-	assets.PlayShootSound()
+	assets.PlayShootSound(p.Collider().CenterX())
 }
 
-func (p *Player) Update() {
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) {
-		p.MoveLeft()
+func (p *Player) Update(controls input.Controls) {
+	if controls.Left {
+		p.MoveLeft(controls.SpeedX())
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyRight) {
-		p.MoveRight()
+	if controls.Right {
+		p.MoveRight(controls.SpeedX())
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyUp) {
-		p.MoveUp()
+	if controls.Up {
+		p.MoveUp(controls.SpeedY())
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyDown) {
-		p.MoveDown()
+	if controls.Down {
+		p.MoveDown(controls.SpeedY())
 	}
-	if ebiten.IsKeyPressed(ebiten.KeySpace) {
+	if controls.Shoot {
 		p.Shoot()
 	}
 
@@ -218,13 +256,49 @@ func (p *Player) Draw(screen *ebiten.Image) {
 		}
 	}
 
+	if p.slot == 1 {
+		op.ColorScale.Scale(0.6, 1, 0.6, 1)
+	}
+
 	op.GeoM.Translate(p.position.X, p.position.Y)
 	screen.DrawImage(p.sprite, op)
 }
 
+// TakeHit is a hit that never costs a life. It reports whether the hit
+// landed, i.e. was not absorbed by a shield or invincibility.
+func (p *Player) TakeHit() bool {
+	if p.hasShield {
+		assets.PlayPowerUpSound(p.Collider().CenterX())
+		return false
+	}
+
+	if p.isInvincible {
+		return false
+	}
+
+	p.isInvincible = true
+	p.invincibilityTimer.Reset()
+	p.game.ResetCombo(p.slot)
+
+	assets.PlayDamageSound(p.Collider().CenterX())
+
+	return true
+}
+
+func (p *Player) IsInvincible() bool {
+	return p.isInvincible
+}
+
+// LoseLife takes a life without any of the hit effects, for co-op ships
+// that draw on this ship's lives. It reports whether none are left.
+func (p *Player) LoseLife() bool {
+	p.lives--
+	return p.lives <= 0
+}
+
 func (p *Player) TakeDamage() bool {
 	if p.hasShield {
-		assets.PlayPowerUpSound()
+		assets.PlayPowerUpSound(p.Collider().CenterX())
 		return false
 	}
 
@@ -239,9 +313,9 @@ func (p *Player) TakeDamage() bool {
 
 	p.isInvincible = true
 	p.invincibilityTimer.Reset()
-	p.game.ResetCombo()
+	p.game.ResetCombo(p.slot)
 
-	assets.PlayDamageSound()
+	assets.PlayDamageSound(p.Collider().CenterX())
 
 	return false
 }
@@ -257,18 +331,33 @@ func (p *Player) Collider() systems.Rect {
 	)
 }
 
+// shipOutline traces the ship sprites' arrowhead as fractions of their
+// bounds, leaving out the transparent corners.
+var shipOutline = []systems.Vector{
+	{X: 0.5, Y: 0},
+	{X: 0.97, Y: 0.55},
+	{X: 0.85, Y: 0.95},
+	{X: 0.15, Y: 0.95},
+	{X: 0.03, Y: 0.55},
+}
+
+// Hitbox is the shape hits are tested against.
+func (p *Player) Hitbox() systems.Shape {
+	return systems.PolygonIn(p.Collider(), 0, shipOutline...)
+}
+
 func (p *Player) GetLives() int {
 	return p.lives
 }
 
 func (p *Player) Heal() {
-	if p.lives < config.InitialLives {
+	if p.lives < p.baseLives {
 		p.lives++
 	}
 }
 
 func (p *Player) GainExtraLife() {
-	if p.lives < config.PlayerMaxTotalLives {
+	if p.lives < p.baseLives+config.PlayerMaxExtraLives {
 		p.lives++
 	}
 }
@@ -29,9 +29,9 @@ type PowerUp struct {
 	powerType PowerUpType
 }
 
-func NewPowerUp() *PowerUp {
+func NewPowerUp(rng *rand.Rand) *PowerUp {
 	pos := systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -100,
 	}
 
@@ -40,7 +40,7 @@ func NewPowerUp() *PowerUp {
 		Y: config.PowerUpSpeed,
 	}
 
-	powerType := PowerUpType(rand.Intn(4))
+	powerType := PowerUpType(rng.Intn(4))
 	var sprite *ebiten.Image
 
 	switch powerType {
@@ -62,9 +62,9 @@ func NewPowerUp() *PowerUp {
 	}
 }
 
-func (p *PowerUp) Reset() {
+func (p *PowerUp) Reset(rng *rand.Rand) {
 	p.position = systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -100,
 	}
 
@@ -73,7 +73,7 @@ func (p *PowerUp) Reset() {
 		Y: config.PowerUpSpeed,
 	}
 
-	p.powerType = PowerUpType(rand.Intn(4))
+	p.powerType = PowerUpType(rng.Intn(4))
 
 	switch p.powerType {
 	case PowerUpHeart:
@@ -115,17 +115,25 @@ func (p *PowerUp) Collider() systems.Rect {
 	)
 }
 
+func (p *PowerUp) Hitbox() systems.Shape {
+	c := p.Collider()
+	return systems.Circle{
+		Center: systems.Vector{X: c.CenterX(), Y: c.Y + c.Height/2},
+		Radius: min(c.Width, c.Height) / 2,
+	}
+}
+
 func (p *PowerUp) IsOutOfScreen() bool {
-	return p.position.Y > config.ScreenHeight+100
+	return p.position.Y > config.FieldHeight()+100
 }
 
 func (p *PowerUp) GetType() PowerUpType {
 	return p.powerType
 }
 
-func NewPowerUpWithType(powerType PowerUpType) *PowerUp {
+func NewPowerUpWithType(rng *rand.Rand, powerType PowerUpType) *PowerUp {
 	pos := systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -100,
 	}
 
@@ -134,31 +142,63 @@ func NewPowerUpWithType(powerType PowerUpType) *PowerUp {
 		Y: config.PowerUpSpeed,
 	}
 
-	var sprite *ebiten.Image
+	return &PowerUp{
+		position:  pos,
+		movement:  movement,
+		sprite:    powerUpSprite(powerType),
+		powerType: powerType,
+	}
+}
+
+var powerUpNames = map[string]PowerUpType{
+	"superShot":  PowerUpSuperShot,
+	"heart":      PowerUpHeart,
+	"shield":     PowerUpShield,
+	"slowMotion": PowerUpSlowMotion,
+	"laser":      PowerUpLaser,
+	"nuke":       PowerUpNuke,
+	"extraLife":  PowerUpExtraLife,
+	"multiplier": PowerUpMultiplier,
+}
+
+// PowerUpTypeFromName maps the names used in waves.json to power-up types.
+func PowerUpTypeFromName(name string) (PowerUpType, bool) {
+	t, ok := powerUpNames[name]
+	return t, ok
+}
+
+// Name is the waves.json name of the power-up type.
+func (t PowerUpType) Name() string {
+	for name, candidate := range powerUpNames {
+		if candidate == t {
+			return name
+		}
+	}
+	return ""
+}
+
+func IsPowerUpName(name string) bool {
+	_, ok := powerUpNames[name]
+	return ok
+}
 
+func powerUpSprite(powerType PowerUpType) *ebiten.Image {
 	switch powerType {
 	case PowerUpHeart:
-		sprite = assets.HeartPowerUpSprite
+		return assets.HeartPowerUpSprite
 	case PowerUpShield:
-		sprite = assets.ShieldPowerUpSprite
+		return assets.ShieldPowerUpSprite
 	case PowerUpSlowMotion:
-		sprite = assets.ClockPowerUpSprite
+		return assets.ClockPowerUpSprite
 	case PowerUpLaser:
-		sprite = assets.LaserPowerUpSprite
+		return assets.LaserPowerUpSprite
 	case PowerUpNuke:
-		sprite = assets.NukePowerUpSprite
+		return assets.NukePowerUpSprite
 	case PowerUpExtraLife:
-		sprite = assets.ExtraLifePowerUpSprite
+		return assets.ExtraLifePowerUpSprite
 	case PowerUpMultiplier:
-		sprite = assets.MultiplierPowerUpSprite
+		return assets.MultiplierPowerUpSprite
 	default:
-		sprite = assets.PowerUpSprites
-	}
-
-	return &PowerUp{
-		position:  pos,
-		movement:  movement,
-		sprite:    sprite,
-		powerType: powerType,
+		return assets.PowerUpSprites
 	}
 }
@@ -37,22 +37,22 @@ func initStarSprites() {
 	starSpritesInitialized = true
 }
 
-func NewStar() *Star {
+func NewStar(rng *rand.Rand) *Star {
 	initStarSprites()
 
 	pos := systems.Vector{
-		X: rand.Float64() * config.ScreenWidth,
+		X: rng.Float64() * config.FieldWidth(),
 		Y: -10,
 	}
 
-	velocity := 2.0 + rand.Float64()*2.0
+	velocity := 2.0 + rng.Float64()*2.0
 
 	movement := systems.Vector{
 		X: 0,
 		Y: velocity,
 	}
 
-	size := 1.0 + rand.Float32()*1.5
+	size := 1.0 + rng.Float32()*1.5
 
 	spriteType := 0
 	if size > 2.0 {
@@ -71,7 +71,7 @@ func NewStar() *Star {
 }
 
 func (m *Star) IsOutOfScreen() bool {
-	return m.position.Y > config.ScreenHeight+100
+	return m.position.Y > config.FieldHeight()+100
 }
 
 func (m *Star) Update() {
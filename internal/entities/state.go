@@ -0,0 +1,345 @@
+package entities
+
+import (
+	"go-meteor/internal/config"
+	"go-meteor/internal/systems"
+	assets "go-meteor/src/pkg"
+)
+
+// The *State types are plain copies of entity fields used to suspend a run
+// to storage. Sprites are not saved; they are looked up again from the
+// entity type on restore.
+
+type PlayerState struct {
+	Position      systems.Vector     `json:"position"`
+	Lives         int                `json:"lives"`
+	Invincible    bool               `json:"invincible"`
+	Shield        bool               `json:"shield"`
+	Slowed        bool               `json:"slowed"`
+	ShootCooldown systems.TimerState `json:"shootCooldown"`
+	Invincibility systems.TimerState `json:"invincibility"`
+	ShieldTimer   systems.TimerState `json:"shieldTimer"`
+	SlowedTimer   systems.TimerState `json:"slowedTimer"`
+}
+
+func (p *Player) State() PlayerState {
+	return PlayerState{
+		Position:      p.position,
+		Lives:         p.lives,
+		Invincible:    p.isInvincible,
+		Shield:        p.hasShield,
+		Slowed:        p.isSlowed,
+		ShootCooldown: p.shootCooldown.State(),
+		Invincibility: p.invincibilityTimer.State(),
+		ShieldTimer:   p.shieldTimer.State(),
+		SlowedTimer:   p.slowedTimer.State(),
+	}
+}
+
+func (p *Player) Restore(s PlayerState) {
+	p.position = s.Position
+	p.lives = s.Lives
+	p.isInvincible = s.Invincible
+	p.hasShield = s.Shield
+	p.isSlowed = s.Slowed
+	p.shootCooldown = systems.RestoreTimer(s.ShootCooldown)
+	p.invincibilityTimer = systems.RestoreTimer(s.Invincibility)
+	p.shieldTimer = systems.RestoreTimer(s.ShieldTimer)
+	p.slowedTimer = systems.RestoreTimer(s.SlowedTimer)
+}
+
+type MeteorState struct {
+	Position      systems.Vector `json:"position"`
+	Movement      systems.Vector `json:"movement"`
+	Rotation      float64        `json:"rotation"`
+	RotationSpeed float64        `json:"rotationSpeed"`
+	Type          MeteorType     `json:"type"`
+	Sprite        int            `json:"sprite"`
+}
+
+func (m *Meteor) State() MeteorState {
+	return MeteorState{
+		Position:      m.position,
+		Movement:      m.movement,
+		Rotation:      m.rotation,
+		RotationSpeed: m.rotationSpeed,
+		Type:          m.meteorType,
+		Sprite:        m.spriteIndex,
+	}
+}
+
+func (m *Meteor) Restore(s MeteorState) {
+	m.position = s.Position
+	m.movement = s.Movement
+	m.rotation = s.Rotation
+	m.rotationSpeed = s.RotationSpeed
+	m.meteorType = s.Type
+	m.spriteIndex = s.Sprite
+	if m.spriteIndex < 0 || m.spriteIndex >= len(assets.MeteorSprites) {
+		m.spriteIndex = 0
+	}
+	m.sprite = assets.MeteorSprites[m.spriteIndex]
+}
+
+type LaserState struct {
+	Position   systems.Vector `json:"position"`
+	Rotation   float64        `json:"rotation"`
+	SuperPower bool           `json:"superPower"`
+	LaserBeam  bool           `json:"laserBeam"`
+	Owner      int            `json:"owner,omitempty"`
+}
+
+func (l *Laser) State() LaserState {
+	return LaserState{
+		Position:   l.position,
+		Rotation:   l.rotation,
+		SuperPower: l.isSuperPower,
+		LaserBeam:  l.isLaserBeam,
+		Owner:      l.owner,
+	}
+}
+
+func RestoreLaser(s LaserState) *Laser {
+	l := NewLaser(systems.Vector{}, s.SuperPower, s.LaserBeam)
+	l.position = s.Position
+	l.rotation = s.Rotation
+	l.owner = s.Owner
+	return l
+}
+
+type PowerUpState struct {
+	Position systems.Vector `json:"position"`
+	Movement systems.Vector `json:"movement"`
+	Type     PowerUpType    `json:"type"`
+}
+
+func (p *PowerUp) State() PowerUpState {
+	return PowerUpState{
+		Position: p.position,
+		Movement: p.movement,
+		Type:     p.powerType,
+	}
+}
+
+func (p *PowerUp) Restore(s PowerUpState) {
+	p.position = s.Position
+	p.movement = s.Movement
+	p.powerType = s.Type
+	p.sprite = powerUpSprite(s.Type)
+}
+
+type BossProjectileState struct {
+	Position systems.Vector   `json:"position"`
+	Velocity systems.Vector   `json:"velocity"`
+	Size     float64          `json:"size"`
+	Accel    float64          `json:"accel,omitempty"`
+	MaxSpeed float64          `json:"maxSpeed,omitempty"`
+	Turn     float64          `json:"turn,omitempty"`
+	Bounces  int              `json:"bounces,omitempty"`
+	Life     int              `json:"life,omitempty"`
+	Age      int              `json:"age,omitempty"`
+	Sprite   ProjectileSprite `json:"sprite,omitempty"`
+	Target   systems.Vector   `json:"target"`
+}
+
+func (bp *BossProjectile) State() BossProjectileState {
+	return BossProjectileState{
+		Position: bp.position,
+		Velocity: bp.velocity,
+		Size:     bp.size,
+		Accel:    bp.accel,
+		MaxSpeed: bp.maxSpeed,
+		Turn:     bp.turn,
+		Bounces:  bp.bounces,
+		Life:     bp.life,
+		Age:      bp.age,
+		Sprite:   bp.sprite,
+		Target:   bp.target,
+	}
+}
+
+func (bp *BossProjectile) Restore(s BossProjectileState) {
+	bp.position = s.Position
+	bp.velocity = s.Velocity
+	bp.size = s.Size
+	bp.accel = s.Accel
+	bp.maxSpeed = s.MaxSpeed
+	bp.turn = s.Turn
+	bp.bounces = s.Bounces
+	bp.life = s.Life
+	bp.age = s.Age
+	bp.sprite = s.Sprite
+	bp.target = s.Target
+}
+
+type CoinState struct {
+	Position  systems.Vector `json:"position"`
+	Movement  systems.Vector `json:"movement"`
+	Value     int            `json:"value"`
+	Collected bool           `json:"collected"`
+	TargetX   float64        `json:"targetX"`
+	TargetY   float64        `json:"targetY"`
+	Speed     float64        `json:"speed"`
+}
+
+func (c *Coin) State() CoinState {
+	return CoinState{
+		Position:  c.position,
+		Movement:  c.movement,
+		Value:     c.value,
+		Collected: c.collected,
+		TargetX:   c.targetX,
+		TargetY:   c.targetY,
+		Speed:     c.speed,
+	}
+}
+
+func RestoreCoin(s CoinState) *Coin {
+	c := NewCoin(s.Position.X, s.Position.Y, s.Value)
+	c.movement = s.Movement
+	c.collected = s.Collected
+	c.targetX = s.TargetX
+	c.targetY = s.TargetY
+	c.speed = s.Speed
+	return c
+}
+
+type MinionState struct {
+	Position    systems.Vector `json:"position"`
+	Velocity    systems.Vector `json:"velocity"`
+	Health      int            `json:"health"`
+	AttackTicks int            `json:"attackTicks"`
+	AttackFired int            `json:"attackFired"`
+	AttackSpin  float64        `json:"attackSpin,omitempty"`
+	Target      systems.Vector `json:"target"`
+	Side        float64        `json:"side"`
+	OffsetX     float64        `json:"offsetX"`
+}
+
+type BossState struct {
+	Type          config.BossType `json:"type"`
+	Position      systems.Vector  `json:"position"`
+	Velocity      systems.Vector  `json:"velocity"`
+	Health        int             `json:"health"`
+	MaxHealth     int             `json:"maxHealth"`
+	Phase         int             `json:"phase,omitempty"`
+	Invulnerable  int             `json:"invulnerable,omitempty"`
+	AttackTicks   []int           `json:"attackTicks"`
+	AttackFired   []int           `json:"attackFired"`
+	AttackSpin    []float64       `json:"attackSpin,omitempty"`
+	CooldownScale float64         `json:"cooldownScale"`
+	MovePattern   int             `json:"movePattern"`
+	PatternTime   float64         `json:"patternTime"`
+	Size          float64         `json:"size"`
+	DamageFlash   int             `json:"damageFlash"`
+	FightTicks    int             `json:"fightTicks"`
+	DamageTaken   int             `json:"damageTaken"`
+	PlayerRef     systems.Vector  `json:"playerRef"`
+	TrackingDelay float64         `json:"trackingDelay"`
+	Direction     float64         `json:"direction"`
+	// Minions keeps destroyed minions as nil so indices stay stable.
+	Minions []*MinionState `json:"minions"`
+}
+
+func (b *Boss) State() BossState {
+	s := BossState{
+		Type:          b.bossType,
+		Position:      b.position,
+		Velocity:      b.velocity,
+		Health:        b.health,
+		MaxHealth:     b.maxHealth,
+		Phase:         b.phase,
+		Invulnerable:  b.invulnerable,
+		AttackTicks:   make([]int, len(b.attacks)),
+		AttackFired:   make([]int, len(b.attacks)),
+		AttackSpin:    make([]float64, len(b.attacks)),
+		CooldownScale: b.cooldownScale,
+		MovePattern:   b.movePattern,
+		PatternTime:   b.patternTime,
+		Size:          b.size,
+		DamageFlash:   b.damageFlash,
+		FightTicks:    b.fightTicks,
+		DamageTaken:   b.damageTaken,
+		PlayerRef:     b.playerRef,
+		TrackingDelay: b.trackingDelay,
+		Direction:     b.direction,
+		Minions:       make([]*MinionState, len(b.minions)),
+	}
+	for i, a := range b.attacks {
+		s.AttackTicks[i] = a.ticks
+		s.AttackFired[i] = a.fired
+		s.AttackSpin[i] = a.spin
+	}
+	for i, m := range b.minions {
+		if m == nil {
+			continue
+		}
+		s.Minions[i] = &MinionState{
+			Position:    m.position,
+			Velocity:    m.velocity,
+			Health:      m.health,
+			AttackTicks: m.attack.ticks,
+			AttackFired: m.attack.fired,
+			AttackSpin:  m.attack.spin,
+			Target:      m.targetPlayer,
+			Side:        m.side,
+			OffsetX:     m.offsetX,
+		}
+	}
+	return s
+}
+
+// RestoreBoss rebuilds a boss saved with State, scripted from book.
+func RestoreBoss(s BossState, book *systems.BossBook) *Boss {
+	script := &book.Bosses[s.Type]
+	b := &Boss{
+		position:      s.Position,
+		velocity:      s.Velocity,
+		health:        s.Health,
+		maxHealth:     s.MaxHealth,
+		movePattern:   s.MovePattern,
+		patternTime:   s.PatternTime,
+		size:          s.Size,
+		sprite:        bossSprites[script.Sprite],
+		bossType:      s.Type,
+		script:        script,
+		patternLength: book.PatternLength,
+		phase:         s.Phase,
+		invulnerable:  s.Invulnerable,
+		attacks:       make([]attackClock, len(script.Phases[s.Phase].Attacks)),
+		cooldownScale: s.CooldownScale,
+		damageFlash:   s.DamageFlash,
+		fightTicks:    s.FightTicks,
+		damageTaken:   s.DamageTaken,
+		playerRef:     s.PlayerRef,
+		trackingDelay: s.TrackingDelay,
+		direction:     s.Direction,
+		minions:       make([]*Minion, len(s.Minions)),
+	}
+	for i := range b.attacks {
+		if i < len(s.AttackTicks) && i < len(s.AttackFired) {
+			b.attacks[i] = attackClock{ticks: s.AttackTicks[i], fired: s.AttackFired[i]}
+		}
+		if i < len(s.AttackSpin) {
+			b.attacks[i].spin = s.AttackSpin[i]
+		}
+	}
+	for i, ms := range s.Minions {
+		if ms == nil {
+			continue
+		}
+		b.minions[i] = &Minion{
+			position:     ms.Position,
+			velocity:     ms.Velocity,
+			health:       ms.Health,
+			size:         config.BossMinionSize,
+			parentBoss:   b,
+			attack:       attackClock{ticks: ms.AttackTicks, fired: ms.AttackFired, spin: ms.AttackSpin},
+			targetPlayer: ms.Target,
+			speed:        minionSpeed,
+			side:         ms.Side,
+			offsetX:      ms.OffsetX,
+		}
+	}
+	return b
+}
@@ -0,0 +1,386 @@
+package input
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Action is something the player can bind keys and a gamepad button to.
+type Action int
+
+const (
+	ActionMoveLeft Action = iota
+	ActionMoveRight
+	ActionMoveUp
+	ActionMoveDown
+	ActionShoot
+	ActionPause
+	ActionPartnerLeft
+	ActionPartnerRight
+	ActionPartnerUp
+	ActionPartnerDown
+	ActionPartnerShoot
+	ActionMenuUp
+	ActionMenuDown
+	ActionMenuLeft
+	ActionMenuRight
+	ActionConfirm
+	ActionBack
+	ActionShop
+	ActionCount
+)
+
+// ActionGroup is where an action is read. Bindings only conflict within
+// groups read together, so Space can both shoot and confirm.
+type ActionGroup int
+
+const (
+	GroupRun ActionGroup = iota
+	// GroupPartner is the co-op partner's half of the shared keyboard. It is
+	// read alongside GroupRun; the partner's gamepad uses the run buttons.
+	GroupPartner
+	GroupMenu
+	// GroupGameOver is read only on the game-over screen, so its keys can
+	// also steer a ship or a menu.
+	GroupGameOver
+)
+
+// sharesKeys reports whether g and o are read at the same time, so one key
+// cannot serve both.
+func (g ActionGroup) sharesKeys(o ActionGroup) bool {
+	if g == GroupMenu || o == GroupMenu || g == GroupGameOver || o == GroupGameOver {
+		return g == o
+	}
+	return true
+}
+
+type actionInfo struct {
+	key   string
+	name  string
+	group ActionGroup
+}
+
+var actions = [ActionCount]actionInfo{
+	ActionMoveLeft:     {"moveLeft", "Move Left", GroupRun},
+	ActionMoveRight:    {"moveRight", "Move Right", GroupRun},
+	ActionMoveUp:       {"moveUp", "Move Up", GroupRun},
+	ActionMoveDown:     {"moveDown", "Move Down", GroupRun},
+	ActionShoot:        {"shoot", "Shoot", GroupRun},
+	ActionPause:        {"pause", "Pause", GroupRun},
+	ActionPartnerLeft:  {"partnerLeft", "P2 Move Left", GroupPartner},
+	ActionPartnerRight: {"partnerRight", "P2 Move Right", GroupPartner},
+	ActionPartnerUp:    {"partnerUp", "P2 Move Up", GroupPartner},
+	ActionPartnerDown:  {"partnerDown", "P2 Move Down", GroupPartner},
+	ActionPartnerShoot: {"partnerShoot", "P2 Shoot", GroupPartner},
+	ActionMenuUp:       {"menuUp", "Menu Up", GroupMenu},
+	ActionMenuDown:     {"menuDown", "Menu Down", GroupMenu},
+	ActionMenuLeft:     {"menuLeft", "Menu Left", GroupMenu},
+	ActionMenuRight:    {"menuRight", "Menu Right", GroupMenu},
+	ActionConfirm:      {"confirm", "Confirm", GroupMenu},
+	ActionBack:         {"back", "Back", GroupMenu},
+	ActionShop:         {"shop", "Open Shop", GroupGameOver},
+}
+
+// Key is the action's name in saved settings.
+func (a Action) Key() string {
+	return actions[a].key
+}
+
+func (a Action) String() string {
+	return actions[a].name
+}
+
+func (a Action) Group() ActionGroup {
+	return actions[a].group
+}
+
+// MaxKeysPerAction is how many keys one action can be bound to.
+const MaxKeysPerAction = 2
+
+// Binding is what triggers one action.
+type Binding struct {
+	Keys    []ebiten.Key
+	Buttons []ebiten.StandardGamepadButton
+}
+
+// Bindings maps every action to its binding.
+type Bindings [ActionCount]Binding
+
+func keys(k ...ebiten.Key) []ebiten.Key {
+	return k
+}
+
+func buttons(b ...ebiten.StandardGamepadButton) []ebiten.StandardGamepadButton {
+	return b
+}
+
+func DefaultBindings() Bindings {
+	return Bindings{
+		ActionMoveLeft:     {keys(ebiten.KeyArrowLeft), buttons(ebiten.StandardGamepadButtonLeftLeft)},
+		ActionMoveRight:    {keys(ebiten.KeyArrowRight), buttons(ebiten.StandardGamepadButtonLeftRight)},
+		ActionMoveUp:       {keys(ebiten.KeyArrowUp), buttons(ebiten.StandardGamepadButtonLeftTop)},
+		ActionMoveDown:     {keys(ebiten.KeyArrowDown), buttons(ebiten.StandardGamepadButtonLeftBottom)},
+		ActionShoot:        {keys(ebiten.KeySpace), buttons(ebiten.StandardGamepadButtonRightBottom)},
+		ActionPause:        {keys(ebiten.KeyEscape, ebiten.KeyP), buttons(ebiten.StandardGamepadButtonCenterRight)},
+		ActionPartnerLeft:  {Keys: keys(ebiten.KeyA)},
+		ActionPartnerRight: {Keys: keys(ebiten.KeyD)},
+		ActionPartnerUp:    {Keys: keys(ebiten.KeyW)},
+		ActionPartnerDown:  {Keys: keys(ebiten.KeyS)},
+		ActionPartnerShoot: {Keys: keys(ebiten.KeyF)},
+		ActionMenuUp:       {keys(ebiten.KeyArrowUp, ebiten.KeyW), buttons(ebiten.StandardGamepadButtonLeftTop)},
+		ActionMenuDown:     {keys(ebiten.KeyArrowDown, ebiten.KeyS), buttons(ebiten.StandardGamepadButtonLeftBottom)},
+		ActionMenuLeft:     {keys(ebiten.KeyArrowLeft, ebiten.KeyA), buttons(ebiten.StandardGamepadButtonLeftLeft)},
+		ActionMenuRight:    {keys(ebiten.KeyArrowRight, ebiten.KeyD), buttons(ebiten.StandardGamepadButtonLeftRight)},
+		ActionConfirm:      {keys(ebiten.KeyEnter, ebiten.KeySpace), buttons(ebiten.StandardGamepadButtonRightBottom)},
+		ActionBack:         {keys(ebiten.KeyEscape), buttons(ebiten.StandardGamepadButtonRightRight)},
+		ActionShop:         {keys(ebiten.KeyS), buttons(ebiten.StandardGamepadButtonRightTop)},
+	}
+}
+
+// The setters below replace slices rather than writing into them, since
+// copies of Bindings share them.
+
+// SetKey binds key to a in slot, appending when slot is past the end.
+func (b *Bindings) SetKey(a Action, slot int, key ebiten.Key) {
+	keys := slices.Clone(b[a].Keys)
+	if slot < len(keys) {
+		keys[slot] = key
+	} else {
+		keys = append(keys, key)
+	}
+	b[a].Keys = keys
+}
+
+func (b *Bindings) ClearKey(a Action, slot int) {
+	if slot < len(b[a].Keys) {
+		b[a].Keys = slices.Delete(slices.Clone(b[a].Keys), slot, slot+1)
+	}
+}
+
+func (b *Bindings) SetButton(a Action, button ebiten.StandardGamepadButton) {
+	b[a].Buttons = buttons(button)
+}
+
+func (b *Bindings) ClearButton(a Action) {
+	b[a].Buttons = nil
+}
+
+// Conflict finds another action read alongside a that already uses key.
+func (b *Bindings) Conflict(a Action, key ebiten.Key) (Action, bool) {
+	for other := Action(0); other < ActionCount; other++ {
+		if other == a || !other.Group().sharesKeys(a.Group()) {
+			continue
+		}
+		for _, k := range b[other].Keys {
+			if k == key {
+				return other, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ButtonConflict is Conflict for gamepad buttons.
+func (b *Bindings) ButtonConflict(a Action, button ebiten.StandardGamepadButton) (Action, bool) {
+	for other := Action(0); other < ActionCount; other++ {
+		if other == a || !other.Group().sharesKeys(a.Group()) {
+			continue
+		}
+		for _, btn := range b[other].Buttons {
+			if btn == button {
+				return other, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// active is the bindings every Source and menu reads.
+var active = DefaultBindings()
+
+func CurrentBindings() Bindings {
+	return active
+}
+
+func SetBindings(b Bindings) {
+	active = b
+}
+
+// ActionPressed reports whether any key or gamepad button bound to a is
+// held.
+func ActionPressed(a Action) bool {
+	if keyPressed(a) {
+		return true
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if padPressed(id, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// ActionJustPressed reports whether a was triggered this tick.
+func ActionJustPressed(a Action) bool {
+	if keyJustPressed(a) {
+		return true
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if padJustPressed(id, a) {
+			return true
+		}
+	}
+	return false
+}
+
+func keyPressed(a Action) bool {
+	for _, k := range active[a].Keys {
+		if ebiten.IsKeyPressed(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func keyJustPressed(a Action) bool {
+	for _, k := range active[a].Keys {
+		if inpututil.IsKeyJustPressed(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func padPressed(id ebiten.GamepadID, a Action) bool {
+	if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+		return false
+	}
+	for _, b := range active[a].Buttons {
+		if ebiten.IsStandardGamepadButtonPressed(id, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func padJustPressed(id ebiten.GamepadID, a Action) bool {
+	if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+		return false
+	}
+	for _, b := range active[a].Buttons {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyLabel names the first key bound to a for on-screen hints.
+func KeyLabel(a Action) string {
+	if len(active[a].Keys) > 0 {
+		return strings.ToUpper(active[a].Keys[0].String())
+	}
+	if len(active[a].Buttons) > 0 {
+		return ButtonName(active[a].Buttons[0])
+	}
+	return "?"
+}
+
+// JustPressedButton returns a standard gamepad button pressed this tick,
+// for rebinding.
+func JustPressedButton() (ebiten.StandardGamepadButton, bool) {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for b := ebiten.StandardGamepadButton(0); b <= ebiten.StandardGamepadButtonMax; b++ {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, b) {
+				return b, true
+			}
+		}
+	}
+	return 0, false
+}
+
+var buttonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "A",
+	ebiten.StandardGamepadButtonRightRight:       "B",
+	ebiten.StandardGamepadButtonRightLeft:        "X",
+	ebiten.StandardGamepadButtonRightTop:         "Y",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "LB",
+	ebiten.StandardGamepadButtonFrontTopRight:    "RB",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "LT",
+	ebiten.StandardGamepadButtonFrontBottomRight: "RT",
+	ebiten.StandardGamepadButtonCenterLeft:       "Select",
+	ebiten.StandardGamepadButtonCenterRight:      "Start",
+	ebiten.StandardGamepadButtonLeftStick:        "LS",
+	ebiten.StandardGamepadButtonRightStick:       "RS",
+	ebiten.StandardGamepadButtonLeftTop:          "D-Up",
+	ebiten.StandardGamepadButtonLeftBottom:       "D-Down",
+	ebiten.StandardGamepadButtonLeftLeft:         "D-Left",
+	ebiten.StandardGamepadButtonLeftRight:        "D-Right",
+	ebiten.StandardGamepadButtonCenterCenter:     "Home",
+}
+
+// ButtonName is a button's label, using Xbox names for the face buttons.
+func ButtonName(b ebiten.StandardGamepadButton) string {
+	return buttonNames[b]
+}
+
+func buttonFromName(name string) (ebiten.StandardGamepadButton, bool) {
+	for b, n := range buttonNames {
+		if n == name {
+			return b, true
+		}
+	}
+	return 0, false
+}
+
+// padPrefix marks gamepad buttons among saved binding names.
+const padPrefix = "pad:"
+
+// Names lists each action's keys and buttons by name, for saving.
+func (b *Bindings) Names() map[string][]string {
+	names := make(map[string][]string, ActionCount)
+	for a := Action(0); a < ActionCount; a++ {
+		list := make([]string, 0, len(b[a].Keys)+len(b[a].Buttons))
+		for _, k := range b[a].Keys {
+			list = append(list, k.String())
+		}
+		for _, btn := range b[a].Buttons {
+			list = append(list, padPrefix+ButtonName(btn))
+		}
+		names[a.Key()] = list
+	}
+	return names
+}
+
+// BindingsFromNames reverses Names. Actions missing from names keep their
+// defaults and unknown names are skipped.
+func BindingsFromNames(names map[string][]string) Bindings {
+	b := DefaultBindings()
+	for a := Action(0); a < ActionCount; a++ {
+		list, ok := names[a.Key()]
+		if !ok {
+			continue
+		}
+		var binding Binding
+		for _, name := range list {
+			if btnName, isPad := strings.CutPrefix(name, padPrefix); isPad {
+				if btn, ok := buttonFromName(btnName); ok && len(binding.Buttons) == 0 {
+					binding.Buttons = append(binding.Buttons, btn)
+				}
+				continue
+			}
+			var k ebiten.Key
+			if k.UnmarshalText([]byte(name)) == nil && len(binding.Keys) < MaxKeysPerAction {
+				binding.Keys = append(binding.Keys, k)
+			}
+		}
+		b[a] = binding
+	}
+	return b
+}
@@ -0,0 +1,45 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestConflictAcrossSharedKeyboard(t *testing.T) {
+	b := DefaultBindings()
+	tests := []struct {
+		action Action
+		key    ebiten.Key
+		want   Action
+		taken  bool
+	}{
+		{ActionShoot, ebiten.KeyF, ActionPartnerShoot, true},
+		{ActionPartnerLeft, ebiten.KeySpace, ActionShoot, true},
+		{ActionPartnerUp, ebiten.KeyS, ActionPartnerDown, true},
+		// Menus are never read during a run.
+		{ActionPartnerUp, ebiten.KeyEnter, 0, false},
+		{ActionMenuUp, ebiten.KeyF, 0, false},
+		// The shop key is only read on the game-over screen.
+		{ActionShop, ebiten.KeyArrowDown, 0, false},
+		{ActionPartnerDown, ebiten.KeyS, 0, false},
+		{ActionMenuDown, ebiten.KeyS, 0, false},
+	}
+	for _, tt := range tests {
+		got, taken := b.Conflict(tt.action, tt.key)
+		if taken != tt.taken || got != tt.want {
+			t.Errorf("Conflict(%s, %s) = %s, %v; want %s, %v", tt.action, tt.key, got, taken, tt.want, tt.taken)
+		}
+	}
+}
+
+func TestDefaultBindingsHaveNoConflicts(t *testing.T) {
+	b := DefaultBindings()
+	for a := Action(0); a < ActionCount; a++ {
+		for _, k := range b[a].Keys {
+			if other, taken := b.Conflict(a, k); taken {
+				t.Errorf("%s and %s both use %s", a, other, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package input
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Controls is the player's intent for a single update tick.
+type Controls struct {
+	Left  bool
+	Right bool
+	Up    bool
+	Down  bool
+	Shoot bool
+	Pause bool
+	// Shop opens the shop from the game-over screen. It is never recorded,
+	// since replays end before that screen.
+	Shop bool
+
+	// AxisX and AxisY scale movement along each axis in steps of
+	// 1/AnalogSteps, for analog sticks. Zero is full speed, as for keys.
+	AxisX uint8
+	AxisY uint8
+}
+
+// AnalogSteps is how finely stick movement is recorded.
+const AnalogSteps = 15
+
+// SpeedX is the share of full speed to move sideways at.
+func (c Controls) SpeedX() float64 {
+	return axisSpeed(c.AxisX)
+}
+
+func (c Controls) SpeedY() float64 {
+	return axisSpeed(c.AxisY)
+}
+
+func axisSpeed(level uint8) float64 {
+	if level == 0 {
+		return 1
+	}
+	return float64(level) / AnalogSteps
+}
+
+// Source produces the Controls for the current tick.
+type Source interface {
+	Poll() Controls
+}
+
+// KeyboardSource reads player one from the bound keys and from every
+// gamepad with the standard layout, except the one lent to the co-op
+// partner.
+type KeyboardSource struct{}
+
+func NewKeyboardSource() *KeyboardSource {
+	return &KeyboardSource{}
+}
+
+func (k *KeyboardSource) Poll() Controls {
+	c := Controls{
+		Left:  keyPressed(ActionMoveLeft),
+		Right: keyPressed(ActionMoveRight),
+		Up:    keyPressed(ActionMoveUp),
+		Down:  keyPressed(ActionMoveDown),
+		Shoot: keyPressed(ActionShoot),
+		Pause: keyJustPressed(ActionPause),
+		Shop:  keyJustPressed(ActionShop),
+	}
+
+	partner, lent := partnerPad()
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if lent && id == partner {
+			continue
+		}
+		c = c.Merge(padControls(id))
+	}
+	return c
+}
+
+// stickDeadzone is how far the left stick must be pushed before the ship
+// moves. Past it, speed grows with the push.
+const stickDeadzone = 0.2
+
+// padControls reads one gamepad through the run bindings and its left
+// stick.
+func padControls(id ebiten.GamepadID) Controls {
+	if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+		return Controls{}
+	}
+	c := stickControls(
+		ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal),
+		ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical),
+	)
+	return c.Merge(Controls{
+		Left:  padPressed(id, ActionMoveLeft),
+		Right: padPressed(id, ActionMoveRight),
+		Up:    padPressed(id, ActionMoveUp),
+		Down:  padPressed(id, ActionMoveDown),
+		Shoot: padPressed(id, ActionShoot),
+		Pause: padJustPressed(id, ActionPause),
+		Shop:  padJustPressed(id, ActionShop),
+	})
+}
+
+// stickControls turns a stick position into directions and per-axis
+// speeds, using a radial deadzone rescaled so speed starts from zero at
+// its edge.
+func stickControls(x, y float64) Controls {
+	mag := math.Hypot(x, y)
+	if mag <= stickDeadzone {
+		return Controls{}
+	}
+	scale := min(1, (mag-stickDeadzone)/(1-stickDeadzone)) / mag
+	levelX := uint8(math.Round(math.Abs(x) * scale * AnalogSteps))
+	levelY := uint8(math.Round(math.Abs(y) * scale * AnalogSteps))
+
+	var c Controls
+	if levelX > 0 {
+		c.Left, c.Right = x < 0, x > 0
+		c.AxisX = levelX
+	}
+	if levelY > 0 {
+		c.Up, c.Down = y < 0, y > 0
+		c.AxisY = levelY
+	}
+	return c
+}
+
+// Merge combines two inputs for one ship, such as the keyboard and a
+// gamepad.
+func (c Controls) Merge(o Controls) Controls {
+	return Controls{
+		Left:  c.Left || o.Left,
+		Right: c.Right || o.Right,
+		Up:    c.Up || o.Up,
+		Down:  c.Down || o.Down,
+		Shoot: c.Shoot || o.Shoot,
+		Pause: c.Pause || o.Pause,
+		Shop:  c.Shop || o.Shop,
+		AxisX: mergeAxis(c.Left || c.Right, c.AxisX, o.Left || o.Right, o.AxisX),
+		AxisY: mergeAxis(c.Up || c.Down, c.AxisY, o.Up || o.Down, o.AxisY),
+	}
+}
+
+// mergeAxis keeps the faster of two inputs on one axis; a key or D-pad
+// always wins with full speed.
+func mergeAxis(aActive bool, a uint8, bActive bool, b uint8) uint8 {
+	switch {
+	case !aActive:
+		return b
+	case !bActive:
+		return a
+	case a == 0 || b == 0:
+		return 0
+	}
+	return max(a, b)
+}
+
+// partnerPadLent is set while a co-op run gives the first gamepad to the
+// partner.
+var partnerPadLent bool
+
+// LendPartnerPad gives the first gamepad to the co-op partner, or back
+// to player one.
+func LendPartnerPad(lent bool) {
+	partnerPadLent = lent
+}
+
+// Rumble shakes the gamepads driving one ship, where the platform
+// supports it: the lent pad for the partner, the others for player one.
+func Rumble(partner bool, duration time.Duration, strength float64) {
+	lentID, lent := partnerPad()
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if partner != (lent && id == lentID) {
+			continue
+		}
+		ebiten.VibrateGamepad(id, &ebiten.VibrateGamepadOptions{
+			Duration:        duration,
+			StrongMagnitude: strength,
+			WeakMagnitude:   strength,
+		})
+	}
+}
+
+func partnerPad() (ebiten.GamepadID, bool) {
+	if !partnerPadLent {
+		return 0, false
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// PartnerSource reads the co-op partner: the partner bindings on the shared
+// keyboard, or the first gamepad with the standard layout.
+type PartnerSource struct{}
+
+func NewPartnerSource() *PartnerSource {
+	return &PartnerSource{}
+}
+
+func (s *PartnerSource) Poll() Controls {
+	c := Controls{
+		Left:  keyPressed(ActionPartnerLeft),
+		Right: keyPressed(ActionPartnerRight),
+		Up:    keyPressed(ActionPartnerUp),
+		Down:  keyPressed(ActionPartnerDown),
+		Shoot: keyPressed(ActionPartnerShoot),
+	}
+	if id, ok := partnerPad(); ok {
+		c = c.Merge(padControls(id))
+	}
+	return c
+}
+
+// InjectedSource returns whatever Controls were last set on it. It is used
+// to drive the game without a window.
+type InjectedSource struct {
+	next Controls
+}
+
+func NewInjectedSource() *InjectedSource {
+	return &InjectedSource{}
+}
+
+func (s *InjectedSource) Set(c Controls) {
+	s.next = c
+}
+
+func (s *InjectedSource) Poll() Controls {
+	return s.next
+}
+
+const (
+	bitLeft uint16 = 1 << iota
+	bitRight
+	bitUp
+	bitDown
+	bitShoot
+	bitPause
+
+	axisXShift = 8
+	axisYShift = 12
+	axisMask   = 0xf
+)
+
+// Bits packs the controls into one frame for recording. Keyboard input
+// stays below 64, so it packs into a single byte.
+func (c Controls) Bits() uint16 {
+	var b uint16
+	if c.Left {
+		b |= bitLeft
+	}
+	if c.Right {
+		b |= bitRight
+	}
+	if c.Up {
+		b |= bitUp
+	}
+	if c.Down {
+		b |= bitDown
+	}
+	if c.Shoot {
+		b |= bitShoot
+	}
+	if c.Pause {
+		b |= bitPause
+	}
+	b |= uint16(c.AxisX&axisMask) << axisXShift
+	b |= uint16(c.AxisY&axisMask) << axisYShift
+	return b
+}
+
+func ControlsFromBits(b uint16) Controls {
+	return Controls{
+		Left:  b&bitLeft != 0,
+		Right: b&bitRight != 0,
+		Up:    b&bitUp != 0,
+		Down:  b&bitDown != 0,
+		Shoot: b&bitShoot != 0,
+		Pause: b&bitPause != 0,
+		AxisX: uint8(b>>axisXShift) & axisMask,
+		AxisY: uint8(b>>axisYShift) & axisMask,
+	}
+}
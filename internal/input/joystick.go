@@ -5,7 +5,10 @@ import (
 	"math"
 	"time"
 
+	"go-meteor/internal/display"
+
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
@@ -22,6 +25,13 @@ type Joystick struct {
 	isActive   bool
 	deltaX     float64
 	deltaY     float64
+
+	// homeX and homeY are where a floating joystick returns when let go.
+	homeX    float64
+	homeY    float64
+	floating bool
+	opacity  float64
+	layout   TouchLayout
 }
 
 func NewJoystick(x, y, radius float64) *Joystick {
@@ -32,13 +42,32 @@ func NewJoystick(x, y, radius float64) *Joystick {
 		knobRadius: radius * 0.4,
 		touchID:    -1,
 		isActive:   false,
+		homeX:      x,
+		homeY:      y,
+		opacity:    1,
+	}
+}
+
+// ApplyLayout moves and resizes the joystick to match l.
+func (j *Joystick) ApplyLayout(l TouchLayout) {
+	j.homeX, j.homeY = l.JoystickPos()
+	j.radius = l.JoystickRadius
+	j.knobRadius = l.JoystickRadius * 0.4
+	j.floating = l.Floating
+	j.opacity = l.Opacity
+	j.layout = l
+	if !j.isActive {
+		j.centerX, j.centerY = j.homeX, j.homeY
 	}
 }
 
 func (j *Joystick) Update(touchIDs []ebiten.TouchID) {
+	if !j.isActive && j.floating {
+		j.spawnUnderThumb()
+	}
 	if !j.isActive {
 		for _, id := range touchIDs {
-			x, y := ebiten.TouchPosition(id)
+			x, y := display.ViewTouchPosition(id)
 			fx, fy := float64(x), float64(y)
 
 			dist := math.Sqrt(math.Pow(fx-j.centerX, 2) + math.Pow(fy-j.centerY, 2))
@@ -55,7 +84,7 @@ func (j *Joystick) Update(touchIDs []ebiten.TouchID) {
 		for _, id := range touchIDs {
 			if id == j.touchID {
 				found = true
-				x, y := ebiten.TouchPosition(id)
+				x, y := display.ViewTouchPosition(id)
 				fx, fy := float64(x), float64(y)
 
 				dx := fx - j.centerX
@@ -79,17 +108,33 @@ func (j *Joystick) Update(touchIDs []ebiten.TouchID) {
 			j.touchID = -1
 			j.deltaX = 0
 			j.deltaY = 0
+			j.centerX, j.centerY = j.homeX, j.homeY
 		}
 	}
 }
 
+// spawnUnderThumb centres a floating joystick on a new touch on its side
+// of the screen, kept far enough from the edges to be pushed all round.
+func (j *Joystick) spawnUnderThumb() {
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := display.ViewTouchPosition(id)
+		fx, fy := float64(x), float64(y)
+		if !j.layout.OnJoystickSide(fx) {
+			continue
+		}
+		j.centerX = clampFloat(fx, j.radius, display.Width()-j.radius)
+		j.centerY = clampFloat(fy, j.radius, display.Height()-j.radius)
+		return
+	}
+}
+
 func (j *Joystick) Draw(screen *ebiten.Image) {
 	baseColor := color.RGBA{100, 100, 100, 120}
 	if j.isActive {
 		baseColor = color.RGBA{150, 150, 150, 180}
 	}
 
-	vector.DrawFilledCircle(screen, float32(j.centerX), float32(j.centerY), float32(j.radius), baseColor, false)
+	vector.DrawFilledCircle(screen, float32(j.centerX), float32(j.centerY), float32(j.radius), fade(baseColor, j.opacity), false)
 
 	knobX := j.centerX + j.deltaX*j.radius*0.6
 	knobY := j.centerY + j.deltaY*j.radius*0.6
@@ -99,7 +144,7 @@ func (j *Joystick) Draw(screen *ebiten.Image) {
 		knobColor = color.RGBA{200, 200, 200, 255}
 	}
 
-	vector.DrawFilledCircle(screen, float32(knobX), float32(knobY), float32(j.knobRadius), knobColor, false)
+	vector.DrawFilledCircle(screen, float32(knobX), float32(knobY), float32(j.knobRadius), fade(knobColor, j.opacity), false)
 }
 
 func (j *Joystick) GetDirection() (float64, float64) {
@@ -110,6 +155,25 @@ func (j *Joystick) IsPressed() bool {
 	return j.isActive && (math.Abs(j.deltaX) > 0.1 || math.Abs(j.deltaY) > 0.1)
 }
 
+// Controls turns the knob's displacement into directions and speeds, the
+// same way as a gamepad stick.
+func (j *Joystick) Controls() Controls {
+	if !j.isActive {
+		return Controls{}
+	}
+	return stickControls(j.deltaX, j.deltaY)
+}
+
+// fade scales a premultiplied colour by opacity.
+func fade(c color.RGBA, opacity float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(float64(c.R) * opacity),
+		G: uint8(float64(c.G) * opacity),
+		B: uint8(float64(c.B) * opacity),
+		A: uint8(float64(c.A) * opacity),
+	}
+}
+
 type ShootButton struct {
 	x             float64
 	y             float64
@@ -118,6 +182,10 @@ type ShootButton struct {
 	isActive      bool
 	wasPressed    bool
 	lastPressTime int64 // For debouncing
+
+	autoFire bool
+	latched  bool
+	opacity  float64
 }
 
 func NewShootButton(x, y, radius float64) *ShootButton {
@@ -128,6 +196,19 @@ func NewShootButton(x, y, radius float64) *ShootButton {
 		touchID:    -1,
 		isActive:   false,
 		wasPressed: false,
+		opacity:    1,
+	}
+}
+
+// ApplyLayout moves and resizes the button to match l. Turning auto-fire
+// off releases a latched button.
+func (sb *ShootButton) ApplyLayout(l TouchLayout) {
+	sb.x, sb.y = l.ShootPos()
+	sb.radius = l.ShootRadius
+	sb.opacity = l.Opacity
+	sb.autoFire = l.AutoFire
+	if !sb.autoFire {
+		sb.latched = false
 	}
 }
 
@@ -141,7 +222,7 @@ func (sb *ShootButton) Update(touchIDs []ebiten.TouchID) bool {
 		}
 
 		for _, id := range touchIDs {
-			x, y := ebiten.TouchPosition(id)
+			x, y := display.ViewTouchPosition(id)
 			fx, fy := float64(x), float64(y)
 
 			dist := math.Sqrt(math.Pow(fx-sb.x, 2) + math.Pow(fy-sb.y, 2))
@@ -152,6 +233,9 @@ func (sb *ShootButton) Update(touchIDs []ebiten.TouchID) bool {
 					pressed = true
 					sb.wasPressed = true
 					sb.lastPressTime = currentTime
+					if sb.autoFire {
+						sb.latched = !sb.latched
+					}
 				}
 				break
 			}
@@ -179,11 +263,20 @@ func (sb *ShootButton) IsActive() bool {
 	return sb.isActive
 }
 
+// IsFiring reports whether the ship should shoot: while the button is
+// held, or while auto-fire has it latched on.
+func (sb *ShootButton) IsFiring() bool {
+	return sb.isActive || sb.latched
+}
+
 func (sb *ShootButton) Draw(screen *ebiten.Image) {
 	buttonColor := color.RGBA{255, 100, 100, 120}
-	if sb.isActive {
+	if sb.IsFiring() {
 		buttonColor = color.RGBA{255, 150, 150, 200}
 	}
 
-	vector.DrawFilledCircle(screen, float32(sb.x), float32(sb.y), float32(sb.radius), buttonColor, false)
+	vector.DrawFilledCircle(screen, float32(sb.x), float32(sb.y), float32(sb.radius), fade(buttonColor, sb.opacity), false)
+	if sb.latched {
+		vector.StrokeCircle(screen, float32(sb.x), float32(sb.y), float32(sb.radius)+3, 3, fade(color.RGBA{255, 215, 0, 200}, sb.opacity), false)
+	}
 }
@@ -0,0 +1,118 @@
+package input
+
+import (
+	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+)
+
+// TouchLayout places the on-screen joystick and shoot button. Positions
+// are control centres on the canvas for right-handed play; the view
+// anchors them to its bottom corners and LeftHanded mirrors them.
+// systems.TouchSettings saves it and must keep the same fields.
+type TouchLayout struct {
+	JoystickX      float64
+	JoystickY      float64
+	JoystickRadius float64
+	ShootX         float64
+	ShootY         float64
+	ShootRadius    float64
+	Opacity        float64
+
+	// Floating spawns the joystick under the thumb instead of at its
+	// fixed place.
+	Floating bool
+	// AutoFire makes a tap on the shoot button latch firing on or off.
+	AutoFire   bool
+	LeftHanded bool
+}
+
+const (
+	MinJoystickRadius = 40.0
+	MaxJoystickRadius = 100.0
+	MinShootRadius    = 30.0
+	MaxShootRadius    = 90.0
+	MinTouchOpacity   = 0.2
+)
+
+func DefaultTouchLayout() TouchLayout {
+	return TouchLayout{
+		JoystickX:      config.JoystickOffsetX,
+		JoystickY:      config.ScreenHeight - config.JoystickOffsetY,
+		JoystickRadius: config.JoystickRadius,
+		ShootX:         config.ScreenWidth - config.ShootButtonOffsetX,
+		ShootY:         config.ScreenHeight - config.ShootButtonOffsetY,
+		ShootRadius:    config.ShootButtonRadius,
+		Opacity:        1,
+	}
+}
+
+// Clamped keeps sizes and opacity in range and each control whole on its
+// own half of the canvas, for layouts loaded from a save.
+func (l TouchLayout) Clamped() TouchLayout {
+	l.JoystickRadius = clampFloat(l.JoystickRadius, MinJoystickRadius, MaxJoystickRadius)
+	l.ShootRadius = clampFloat(l.ShootRadius, MinShootRadius, MaxShootRadius)
+	l.Opacity = clampFloat(l.Opacity, MinTouchOpacity, 1)
+
+	half := float64(config.ScreenWidth) / 2
+	l.JoystickX = clampFloat(l.JoystickX, l.JoystickRadius, half-l.JoystickRadius)
+	l.JoystickY = clampFloat(l.JoystickY, l.JoystickRadius, config.ScreenHeight-l.JoystickRadius)
+	l.ShootX = clampFloat(l.ShootX, half+l.ShootRadius, config.ScreenWidth-l.ShootRadius)
+	l.ShootY = clampFloat(l.ShootY, l.ShootRadius, config.ScreenHeight-l.ShootRadius)
+	return l
+}
+
+// JoystickPos is where the joystick rests in the view. It keeps its
+// distance to the bottom-left corner, and the shoot button to the
+// bottom-right, however wide or tall the view is; in portrait that puts
+// both in the control strip.
+func (l TouchLayout) JoystickPos() (float64, float64) {
+	x, y := display.Place(display.ControlsLeft, l.JoystickX, l.JoystickY)
+	return l.mirror(x), y
+}
+
+func (l TouchLayout) ShootPos() (float64, float64) {
+	x, y := display.Place(display.ControlsRight, l.ShootX, l.ShootY)
+	return l.mirror(x), y
+}
+
+// SetJoystickPos moves the joystick to a point in the view, as dragged in
+// the layout editor.
+func (l *TouchLayout) SetJoystickPos(x, y float64) {
+	l.JoystickX, l.JoystickY = display.Unplace(display.ControlsLeft, l.mirror(x), y)
+	*l = l.Clamped()
+}
+
+func (l *TouchLayout) SetShootPos(x, y float64) {
+	l.ShootX, l.ShootY = display.Unplace(display.ControlsRight, l.mirror(x), y)
+	*l = l.Clamped()
+}
+
+// mirror flips x across the view for left-handed play. It is its own
+// inverse.
+func (l TouchLayout) mirror(x float64) float64 {
+	if l.LeftHanded {
+		return display.Width() - x
+	}
+	return x
+}
+
+// OnJoystickSide reports whether x is on the joystick's half of the view,
+// where a floating joystick may spawn.
+func (l TouchLayout) OnJoystickSide(x float64) bool {
+	return (x < display.Width()/2) != l.LeftHanded
+}
+
+// touchLayout is the layout the touch controls and the editor share.
+var touchLayout = DefaultTouchLayout()
+
+func CurrentTouchLayout() TouchLayout {
+	return touchLayout
+}
+
+func SetTouchLayout(l TouchLayout) {
+	touchLayout = l.Clamped()
+}
+
+func clampFloat(v, lo, hi float64) float64 {
+	return max(lo, min(hi, v))
+}
@@ -0,0 +1,248 @@
+package systems
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed bosses.json
+var defaultBosses []byte
+
+// BossBook describes every boss the game can spawn. Bosses are picked by
+// their index, so appending a boss keeps the earlier ones' indices.
+//
+// A boss fights in phases, each starting once its health fraction drops to
+// FromHealth, such as 0.66 and 0.33. Entering a phase makes the boss briefly
+// invulnerable and, with RespawnMinions, brings back destroyed minions. A
+// phase cycles through its moves, switching to the next when the pattern
+// clock passes PatternLength; the clock advances 0.05 a tick and restarts
+// with each move. Every attack in the phase fires on its own cooldown, and
+// each minion fires MinionAttack on its own.
+type BossBook struct {
+	PatternLength float64      `json:"patternLength"`
+	Bosses        []BossScript `json:"bosses"`
+}
+
+type BossScript struct {
+	Name       string  `json:"name"`
+	Sprite     string  `json:"sprite"`
+	Health     int     `json:"health"`
+	EntrySpeed float64 `json:"entrySpeed"`
+	// Size is the collider's side and half the drawn width.
+	Size         float64     `json:"size"`
+	Minions      int         `json:"minions"`
+	MinionAttack BossAttack  `json:"minionAttack"`
+	Phases       []BossPhase `json:"phases"`
+}
+
+type BossPhase struct {
+	FromHealth     float64      `json:"fromHealth"`
+	RespawnMinions bool         `json:"respawnMinions,omitempty"`
+	Moves          []BossMove   `json:"moves"`
+	Attacks        []BossAttack `json:"attacks"`
+}
+
+// Movement kinds. The first move of a fight is picked at random.
+const (
+	// MoveBounce drifts Speed a tick, turning at the field's edges, plus a
+	// sine wobble of Wobble at WobbleFreq.
+	MoveBounce = "bounce"
+	// MoveZigzag goes right then left at Speed, turning every half Period
+	// tenths of the pattern clock.
+	MoveZigzag = "zigzag"
+	// MoveTrack eases an aim point towards the nearest ship by Follow a
+	// tick and steps Speed towards it unless within Deadzone.
+	MoveTrack = "track"
+	// MoveSweep swings across the centre on a cosine of Radius at Freq,
+	// keeping Margin from the edges.
+	MoveSweep = "sweep"
+)
+
+type BossMove struct {
+	Kind       string  `json:"kind"`
+	Speed      float64 `json:"speed,omitempty"`
+	Wobble     float64 `json:"wobble,omitempty"`
+	WobbleFreq float64 `json:"wobbleFreq,omitempty"`
+	Period     int     `json:"period,omitempty"`
+	Follow     float64 `json:"follow,omitempty"`
+	Deadzone   float64 `json:"deadzone,omitempty"`
+	Radius     float64 `json:"radius,omitempty"`
+	Freq       float64 `json:"freq,omitempty"`
+	Margin     float64 `json:"margin,omitempty"`
+}
+
+// BossAttack fires volleys of Count projectiles at Speed. Spawn points are
+// SpacingX apart, centred under the shooter and OffsetY below it;
+// directions fan out over SpreadDeg around straight down, or around the
+// nearest ship when Aimed, and the fan turns SpinDeg further with every
+// volley for spirals. After each cooldown Burst volleys fire BurstGapMs
+// apart. Cooldowns are scaled by difficulty.
+//
+// Each projectile gains Accel speed a tick, never dropping below 1 nor
+// passing MaxSpeed when set. A homing shot steers towards the nearest ship
+// by up to TurnDeg a tick, a bouncing one comes back off the field's sides
+// Bounces times, and any shot with LifetimeMs fizzles out after it. Sprite
+// picks the look, a plain orb by default.
+type BossAttack struct {
+	CooldownMs int     `json:"cooldownMs"`
+	Count      int     `json:"count"`
+	SpacingX   float64 `json:"spacingX,omitempty"`
+	SpreadDeg  float64 `json:"spreadDeg,omitempty"`
+	SpinDeg    float64 `json:"spinDeg,omitempty"`
+	Speed      float64 `json:"speed"`
+	OffsetY    float64 `json:"offsetY,omitempty"`
+	Aimed      bool    `json:"aimed,omitempty"`
+	Burst      int     `json:"burst,omitempty"`
+	BurstGapMs int     `json:"burstGapMs,omitempty"`
+	Accel      float64 `json:"accel,omitempty"`
+	MaxSpeed   float64 `json:"maxSpeed,omitempty"`
+	TurnDeg    float64 `json:"turnDeg,omitempty"`
+	Bounces    int     `json:"bounces,omitempty"`
+	LifetimeMs int     `json:"lifetimeMs,omitempty"`
+	Sprite     string  `json:"sprite,omitempty"`
+}
+
+// BossSprites reports which sprite names the game can draw, for bosses and
+// for their projectiles.
+type BossSprites struct {
+	Boss       func(string) bool
+	Projectile func(string) bool
+}
+
+// LoadBossBook parses the embedded boss book.
+func LoadBossBook(sprites BossSprites) (*BossBook, error) {
+	book, err := parseBossBook(defaultBosses, sprites)
+	if err != nil {
+		return nil, fmt.Errorf("embedded bosses.json: %w", err)
+	}
+	return book, nil
+}
+
+func parseBossBook(data []byte, sprites BossSprites) (*BossBook, error) {
+	var b BossBook
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	if err := b.validate(sprites); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (b *BossBook) validate(sprites BossSprites) error {
+	if b.PatternLength <= 0 {
+		return fmt.Errorf("patternLength must be positive")
+	}
+	if len(b.Bosses) == 0 {
+		return fmt.Errorf("at least one boss is needed")
+	}
+	for i, s := range b.Bosses {
+		if err := s.validate(sprites); err != nil {
+			return fmt.Errorf("bosses[%d] (%s): %w", i, s.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *BossScript) validate(sprites BossSprites) error {
+	if !sprites.Boss(s.Sprite) {
+		return fmt.Errorf("unknown sprite %q", s.Sprite)
+	}
+	if s.Health < 1 || s.EntrySpeed <= 0 || s.Size <= 0 {
+		return fmt.Errorf("health, entrySpeed and size must be positive")
+	}
+	if s.Minions < 0 || s.Minions > 3 {
+		return fmt.Errorf("minions must be 0 to 3")
+	}
+	if s.Minions > 0 {
+		if err := s.MinionAttack.validate(sprites.Projectile); err != nil {
+			return fmt.Errorf("minionAttack: %w", err)
+		}
+	}
+	if len(s.Phases) == 0 || s.Phases[0].FromHealth != 1 {
+		return fmt.Errorf("phases must start at fromHealth 1")
+	}
+	for i, p := range s.Phases {
+		if i > 0 && (p.FromHealth >= s.Phases[i-1].FromHealth || p.FromHealth <= 0) {
+			return fmt.Errorf("phases[%d]: fromHealth must decrease and stay positive", i)
+		}
+		if len(p.Moves) == 0 {
+			return fmt.Errorf("phases[%d]: at least one move is needed", i)
+		}
+		for j, m := range p.Moves {
+			if err := m.validate(); err != nil {
+				return fmt.Errorf("phases[%d].moves[%d]: %w", i, j, err)
+			}
+		}
+		for j, a := range p.Attacks {
+			if err := a.validate(sprites.Projectile); err != nil {
+				return fmt.Errorf("phases[%d].attacks[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// PhaseMarks lists the health fractions at which later phases start.
+func (s *BossScript) PhaseMarks() []float64 {
+	marks := make([]float64, 0, len(s.Phases)-1)
+	for _, p := range s.Phases[1:] {
+		marks = append(marks, p.FromHealth)
+	}
+	return marks
+}
+
+func (m BossMove) validate() error {
+	switch m.Kind {
+	case MoveBounce:
+		if m.Speed <= 0 {
+			return fmt.Errorf("bounce needs a positive speed")
+		}
+	case MoveZigzag:
+		if m.Speed <= 0 || m.Period < 2 {
+			return fmt.Errorf("zigzag needs a positive speed and a period of at least 2")
+		}
+	case MoveTrack:
+		if m.Speed <= 0 || m.Follow <= 0 || m.Follow > 1 || m.Deadzone < 0 {
+			return fmt.Errorf("track needs a positive speed, follow up to 1 and a deadzone not negative")
+		}
+	case MoveSweep:
+		if m.Radius <= 0 || m.Freq <= 0 || m.Margin < 0 {
+			return fmt.Errorf("sweep needs a positive radius and freq and a margin not negative")
+		}
+	default:
+		return fmt.Errorf("unknown kind %q", m.Kind)
+	}
+	return nil
+}
+
+func (a BossAttack) validate(isSprite func(string) bool) error {
+	if a.CooldownMs <= 0 || a.Count < 1 || a.Speed <= 0 {
+		return fmt.Errorf("cooldownMs, count and speed must be positive")
+	}
+	if a.SpacingX < 0 || a.SpreadDeg < 0 || a.SpreadDeg >= 360 {
+		return fmt.Errorf("spacingX must not be negative and spreadDeg must be under 360")
+	}
+	if a.Burst < 0 || (a.Burst > 1 && a.BurstGapMs <= 0) {
+		return fmt.Errorf("burst must not be negative and needs a positive burstGapMs")
+	}
+	if a.MaxSpeed < 0 || (a.MaxSpeed > 0 && a.MaxSpeed < a.Speed) {
+		return fmt.Errorf("maxSpeed must not be below speed")
+	}
+	if a.TurnDeg < 0 || a.Bounces < 0 || a.LifetimeMs < 0 {
+		return fmt.Errorf("turnDeg, bounces and lifetimeMs must not be negative")
+	}
+	if a.TurnDeg > 0 && a.LifetimeMs == 0 {
+		return fmt.Errorf("homing shots need a lifetimeMs")
+	}
+	if a.Sprite != "" && !isSprite(a.Sprite) {
+		return fmt.Errorf("unknown sprite %q", a.Sprite)
+	}
+	return nil
+}
+
+// Volleys is how many volleys follow each cooldown.
+func (a BossAttack) Volleys() int {
+	return max(1, a.Burst)
+}
@@ -0,0 +1,103 @@
+package systems
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testSprites accepts the sprite names the game draws.
+var testSprites = BossSprites{
+	Boss: func(name string) bool {
+		return slices.Contains([]string{"tank", "sniper", "swarm"}, name)
+	},
+	Projectile: func(name string) bool {
+		return slices.Contains([]string{"orb", "needle", "missile", "pellet"}, name)
+	},
+}
+
+func TestEmbeddedBossBook(t *testing.T) {
+	book, err := LoadBossBook(testSprites)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name  string
+		marks []float64
+	}{
+		{"tank", []float64{0.66, 0.33}},
+		{"sniper", []float64{0.66, 0.33}},
+		{"swarm", []float64{0.66, 0.33}},
+	}
+	if len(book.Bosses) < len(want) {
+		t.Fatalf("book has %d bosses, want at least %d", len(book.Bosses), len(want))
+	}
+	// Bosses are picked by index, so the originals must keep theirs.
+	for i, w := range want {
+		b := book.Bosses[i]
+		if b.Name != w.name {
+			t.Errorf("boss %d is %q, want %q", i, b.Name, w.name)
+			continue
+		}
+		if got := b.PhaseMarks(); !slices.Equal(got, w.marks) {
+			t.Errorf("%s phase marks = %v, want %v", b.Name, got, w.marks)
+		}
+	}
+}
+
+func TestBossBookRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		spoil func(b *BossBook)
+		want  string
+	}{
+		{"no bosses", func(b *BossBook) { b.Bosses = nil }, "at least one boss"},
+		{"zero pattern length", func(b *BossBook) { b.PatternLength = 0 }, "patternLength"},
+		{"unknown boss sprite", func(b *BossBook) { b.Bosses[0].Sprite = "blimp" }, "unknown sprite"},
+		{"no health", func(b *BossBook) { b.Bosses[0].Health = 0 }, "health"},
+		{"too many minions", func(b *BossBook) { b.Bosses[0].Minions = 4 }, "minions"},
+		{"empty phases", func(b *BossBook) { b.Bosses[0].Phases = nil }, "fromHealth 1"},
+		{"phases out of order", func(b *BossBook) { b.Bosses[0].Phases[2].FromHealth = 0.9 }, "phases[2]"},
+		{"phase without moves", func(b *BossBook) { b.Bosses[1].Phases[1].Moves = nil }, "at least one move"},
+		{"unknown move", func(b *BossBook) { b.Bosses[0].Phases[0].Moves[0].Kind = "teleport" }, "unknown kind"},
+		{"bounce without speed", func(b *BossBook) { b.Bosses[0].Phases[0].Moves[0] = BossMove{Kind: MoveBounce} }, "bounce"},
+		{"unknown attack sprite", func(b *BossBook) { b.Bosses[0].Phases[0].Attacks[0].Sprite = "anvil" }, "unknown sprite"},
+		{"full circle spread", func(b *BossBook) { b.Bosses[0].Phases[0].Attacks[0].SpreadDeg = 360 }, "spreadDeg"},
+		{"negative spread", func(b *BossBook) { b.Bosses[0].Phases[0].Attacks[0].SpreadDeg = -10 }, "spreadDeg"},
+		{"no projectiles", func(b *BossBook) { b.Bosses[0].Phases[0].Attacks[0].Count = 0 }, "count"},
+		{"burst without gap", func(b *BossBook) {
+			a := &b.Bosses[0].Phases[0].Attacks[0]
+			a.Burst, a.BurstGapMs = 3, 0
+		}, "burstGapMs"},
+		{"homing forever", func(b *BossBook) {
+			a := &b.Bosses[0].Phases[0].Attacks[0]
+			a.TurnDeg, a.LifetimeMs = 2, 0
+		}, "lifetimeMs"},
+		{"bad minion attack", func(b *BossBook) { b.Bosses[0].MinionAttack.CooldownMs = 0 }, "minionAttack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, err := parseBossBook(defaultBosses, testSprites)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.spoil(book)
+			data, err := json.Marshal(book)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = parseBossBook(data, testSprites)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBossBookRejectsMalformedJSON(t *testing.T) {
+	if _, err := parseBossBook([]byte(`{"patternLength": 100, "bosses": [`), testSprites); err == nil {
+		t.Error("truncated book accepted")
+	}
+}
@@ -21,6 +21,10 @@ func NewRect(x, y, width, height float64) Rect {
 	}
 }
 
+func (r Rect) CenterX() float64 {
+	return r.X + r.Width/2
+}
+
 func (r Rect) MaxX() float64 {
 	return r.X + r.Width
 }
@@ -0,0 +1,84 @@
+package systems
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"time"
+)
+
+type DailyModifier string
+
+const (
+	ModifierIceOnly          DailyModifier = "iceOnly"
+	ModifierNoShields        DailyModifier = "noShields"
+	ModifierDoubleBossHealth DailyModifier = "doubleBossHealth"
+	ModifierFastMeteors      DailyModifier = "fastMeteors"
+)
+
+const DailyModifiersPerDay = 2
+
+var dailyModifiers = []DailyModifier{
+	ModifierIceOnly,
+	ModifierNoShields,
+	ModifierDoubleBossHealth,
+	ModifierFastMeteors,
+}
+
+var dailyModifierNames = map[DailyModifier]string{
+	ModifierIceOnly:          "Only Ice Meteors",
+	ModifierNoShields:        "No Shields",
+	ModifierDoubleBossHealth: "Double Boss Health",
+	ModifierFastMeteors:      "Fast Meteors",
+}
+
+func (m DailyModifier) String() string {
+	if name, ok := dailyModifierNames[m]; ok {
+		return name
+	}
+	return string(m)
+}
+
+// DailyChallenge is the run every player gets on a given UTC date. Seed and
+// modifiers depend on nothing but the date.
+type DailyChallenge struct {
+	Date      string
+	Seed      int64
+	Modifiers []DailyModifier
+}
+
+// DailyDate is the challenge date for t, the same everywhere in the world.
+func DailyDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+func NewDailyChallenge(date string) DailyChallenge {
+	sum := sha256.Sum256([]byte("go-meteor-daily|" + date))
+	seed := int64(binary.BigEndian.Uint64(sum[:8]))
+
+	// A seeded math/rand source produces the same sequence on every
+	// platform and Go release, so the pick is stable.
+	picks := rand.New(rand.NewSource(seed)).Perm(len(dailyModifiers))
+	modifiers := make([]DailyModifier, 0, DailyModifiersPerDay)
+	for _, i := range picks[:DailyModifiersPerDay] {
+		modifiers = append(modifiers, dailyModifiers[i])
+	}
+
+	return DailyChallenge{
+		Date:      date,
+		Seed:      seed,
+		Modifiers: modifiers,
+	}
+}
+
+func (d *DailyChallenge) Has(m DailyModifier) bool {
+	if d == nil {
+		return false
+	}
+	for _, candidate := range d.Modifiers {
+		if candidate == m {
+			return true
+		}
+	}
+	return false
+}
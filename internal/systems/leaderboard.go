@@ -9,9 +9,25 @@ import (
 const MaxLeaderboardEntries = 10
 
 type LeaderboardEntry struct {
-	Name  string `json:"name"`
-	Score int    `json:"score"`
-	Date  string `json:"date"`
+	Name       string `json:"name"`
+	Score      int    `json:"score"`
+	Date       string `json:"date"`
+	Difficulty string `json:"difficulty,omitempty"`
+	Mode       string `json:"mode,omitempty"`
+	Challenge  string `json:"challenge,omitempty"`
+}
+
+// LeaderboardCategory identifies one ranking: scores are only compared
+// against runs of the same mode played on the same difficulty. Daily
+// challenges also set Challenge, giving each day its own table.
+type LeaderboardCategory struct {
+	Mode       string
+	Difficulty string
+	Challenge  string
+}
+
+func (e LeaderboardEntry) Category() LeaderboardCategory {
+	return LeaderboardCategory{Mode: e.Mode, Difficulty: e.Difficulty, Challenge: e.Challenge}
 }
 
 type Leaderboard struct {
@@ -24,26 +40,47 @@ func NewLeaderboard() *Leaderboard {
 	}
 }
 
-func (l *Leaderboard) AddScore(name string, score int) {
+// Each category keeps its own top MaxLeaderboardEntries.
+func (l *Leaderboard) AddScore(name string, score int, category LeaderboardCategory) {
 	entry := LeaderboardEntry{
-		Name:  name,
-		Score: score,
-		Date:  time.Now().Format("2006-01-02"),
+		Name:       name,
+		Score:      score,
+		Date:       time.Now().Format("2006-01-02"),
+		Difficulty: category.Difficulty,
+		Mode:       category.Mode,
+		Challenge:  category.Challenge,
 	}
 
 	l.Entries = append(l.Entries, entry)
 	l.Sort()
 
-	if len(l.Entries) > MaxLeaderboardEntries {
-		l.Entries = l.Entries[:MaxLeaderboardEntries]
+	counts := make(map[LeaderboardCategory]int)
+	kept := l.Entries[:0]
+	for _, e := range l.Entries {
+		if counts[e.Category()] < MaxLeaderboardEntries {
+			counts[e.Category()]++
+			kept = append(kept, e)
+		}
+	}
+	l.Entries = kept
+}
+
+func (l *Leaderboard) EntriesFor(category LeaderboardCategory) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0, MaxLeaderboardEntries)
+	for _, e := range l.Entries {
+		if e.Category() == category {
+			entries = append(entries, e)
+		}
 	}
+	return entries
 }
 
-func (l *Leaderboard) IsTopScore(score int) bool {
-	if len(l.Entries) < MaxLeaderboardEntries {
+func (l *Leaderboard) IsTopScore(score int, category LeaderboardCategory) bool {
+	entries := l.EntriesFor(category)
+	if len(entries) < MaxLeaderboardEntries {
 		return true
 	}
-	return score > l.Entries[MaxLeaderboardEntries-1].Score
+	return score > entries[MaxLeaderboardEntries-1].Score
 }
 
 func (l *Leaderboard) Sort() {
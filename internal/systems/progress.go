@@ -13,6 +13,7 @@ type PlayerProgress struct {
 	Upgrades      map[string]int `json:"upgrades"`
 	OwnedSkins    []string       `json:"ownedSkins"`
 	EquippedSkin  string         `json:"equippedSkin"`
+	DailyAttempt  string         `json:"dailyAttempt,omitempty"`
 	Version       int            `json:"version"`
 	Checksum      string         `json:"checksum"`
 }
@@ -29,8 +30,8 @@ func NewPlayerProgress() *PlayerProgress {
 		Coins:         0,
 		CoinsLifetime: 0,
 		Upgrades:      make(map[string]int),
-		OwnedSkins:    []string{"gray"},
-		EquippedSkin:  "gray",
+		OwnedSkins:    []string{DefaultSkin},
+		EquippedSkin:  DefaultSkin,
 		Version:       ProgressVersion,
 	}
 }
@@ -60,16 +61,36 @@ func (p *PlayerProgress) GetUpgradeLevel(powerType string) int {
 
 func (p *PlayerProgress) UpgradePower(powerType string) bool {
 	currentLevel := p.GetUpgradeLevel(powerType)
-	if currentLevel >= MaxUpgradeLevel {
+	upgrade, ok := UpgradeByID(powerType)
+	if !ok || currentLevel >= upgrade.MaxLevel {
 		return false
 	}
 	p.Upgrades[powerType] = currentLevel + 1
 	return true
 }
 
+// ClaimDailyAttempt uses up the scored attempt for date. It reports false
+// if that attempt was already used.
+func (p *PlayerProgress) ClaimDailyAttempt(date string) bool {
+	if p.DailyAttempt == date {
+		return false
+	}
+	p.DailyAttempt = date
+	return true
+}
+
+func (p *PlayerProgress) HasDailyAttempt(date string) bool {
+	return p.DailyAttempt != date
+}
+
 func (p *PlayerProgress) CalculateChecksum() string {
 	data := fmt.Sprintf("%d|%d|%v|%v|%s|%d|%s",
 		p.Coins, p.CoinsLifetime, p.Upgrades, p.OwnedSkins, p.EquippedSkin, p.Version, checksumSalt)
+	// Appended only when set so saves from before daily challenges still
+	// validate.
+	if p.DailyAttempt != "" {
+		data += "|" + p.DailyAttempt
+	}
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
@@ -118,10 +139,10 @@ func PlayerProgressFromJSON(jsonData string) (*PlayerProgress, error) {
 		progress.Upgrades = make(map[string]int)
 	}
 	if len(progress.OwnedSkins) == 0 {
-		progress.OwnedSkins = []string{"gray"}
+		progress.OwnedSkins = []string{DefaultSkin}
 	}
 	if progress.EquippedSkin == "" {
-		progress.EquippedSkin = "gray"
+		progress.EquippedSkin = DefaultSkin
 	}
 	if err := progress.Validate(); err != nil {
 		return nil, err
@@ -0,0 +1,147 @@
+package systems
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// Replay is everything needed to re-simulate a run: the seed, the loadout
+// that affects gameplay and one packed input frame per player per
+// simulated tick. Co-op frames are interleaved, player one first.
+type Replay struct {
+	Version    string         `json:"version"`
+	Seed       int64          `json:"seed"`
+	Skin       string         `json:"skin"`
+	Upgrades   map[string]int `json:"upgrades,omitempty"`
+	Waves      string         `json:"waves"`
+	Difficulty string         `json:"difficulty"`
+	Mode       string         `json:"mode"`
+	Daily      string         `json:"daily,omitempty"`
+	Players    int            `json:"players,omitempty"`
+	// Portrait runs are simulated on the taller portrait field.
+	Portrait   bool   `json:"portrait,omitempty"`
+	FinalScore int    `json:"finalScore"`
+	Ticks      int    `json:"ticks"`
+	Frames     string `json:"frames"`
+
+	frames []uint16
+}
+
+// maxReplayTicks bounds the length a stored replay may claim: a day of
+// play at 60 ticks a second. Replays come back from storage and snapshots,
+// which may be corrupt, so their header is not trusted to size buffers.
+const maxReplayTicks = 24 * 60 * 60 * 60
+
+func NewReplay(version string, seed int64, skin string, upgrades map[string]int) *Replay {
+	copied := make(map[string]int, len(upgrades))
+	for k, v := range upgrades {
+		copied[k] = v
+	}
+	return &Replay{
+		Version:  version,
+		Seed:     seed,
+		Skin:     skin,
+		Upgrades: copied,
+		frames:   make([]uint16, 0, 4096),
+	}
+}
+
+// stride is the number of frames stored per tick.
+func (r *Replay) stride() int {
+	return max(1, r.Players)
+}
+
+// Record stores one tick, with a frame for each player.
+func (r *Replay) Record(frames ...uint16) {
+	r.frames = append(r.frames, frames...)
+	r.Ticks = r.Len()
+}
+
+func (r *Replay) Frame(tick, slot int) uint16 {
+	return r.frames[tick*r.stride()+slot]
+}
+
+// Len is the number of recorded ticks.
+func (r *Replay) Len() int {
+	return len(r.frames) / r.stride()
+}
+
+func (r *Replay) Finish(finalScore int) {
+	r.FinalScore = finalScore
+	r.Ticks = r.Len()
+}
+
+func (r *Replay) ToJSON() (string, error) {
+	r.Frames = base64.StdEncoding.EncodeToString(encodeFrames(r.frames))
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func ReplayFromJSON(jsonData string) (*Replay, error) {
+	var r Replay
+	if err := json.Unmarshal([]byte(jsonData), &r); err != nil {
+		return nil, err
+	}
+	if r.Ticks < 0 || r.Ticks > maxReplayTicks || r.Players < 0 || r.Players > 2 {
+		return nil, fmt.Errorf("replay has %d ticks for %d players", r.Ticks, r.Players)
+	}
+	packed, err := base64.StdEncoding.DecodeString(r.Frames)
+	if err != nil {
+		return nil, err
+	}
+	frames, err := decodeFrames(packed, r.Ticks*r.stride())
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) != r.Ticks*r.stride() {
+		return nil, fmt.Errorf("replay has %d frames, expected %d", len(frames), r.Ticks*r.stride())
+	}
+	r.frames = frames
+	return &r, nil
+}
+
+// encodeFrames run-length encodes frames as (uvarint value, uvarint count)
+// pairs. Inputs are held for many ticks at a time, so this stays small.
+// Values below 128 take one byte, as they did when frames were bytes.
+func encodeFrames(frames []uint16) []byte {
+	out := make([]byte, 0, len(frames)/4)
+	for i := 0; i < len(frames); {
+		j := i + 1
+		for j < len(frames) && frames[j] == frames[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(frames[i]))
+		out = binary.AppendUvarint(out, uint64(j-i))
+		i = j
+	}
+	return out
+}
+
+// decodeFrames reverses encodeFrames, refusing to produce more than limit
+// frames so a corrupt run length cannot exhaust memory.
+func decodeFrames(packed []byte, limit int) ([]uint16, error) {
+	frames := make([]uint16, 0, min(len(packed)*4, limit))
+	for i := 0; i < len(packed); {
+		value, n := binary.Uvarint(packed[i:])
+		if n <= 0 || value > 0xffff {
+			return nil, fmt.Errorf("corrupt replay frames at byte %d", i)
+		}
+		count, m := binary.Uvarint(packed[i+n:])
+		if m <= 0 {
+			return nil, fmt.Errorf("corrupt replay frames at byte %d", i)
+		}
+		if count > uint64(limit-len(frames)) {
+			return nil, fmt.Errorf("replay frames run past %d at byte %d", limit, i)
+		}
+		for k := uint64(0); k < count; k++ {
+			frames = append(frames, uint16(value))
+		}
+		i += n + m
+	}
+	return frames, nil
+}
@@ -0,0 +1,95 @@
+package systems
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReplayRoundTrip(t *testing.T) {
+	for _, players := range []int{0, 2} {
+		r := NewReplay("1.0", 99, "red", map[string]int{"fireRate": 2})
+		r.Players = players
+		for tick := 0; tick < 500; tick++ {
+			// Long holds, quick taps and values past one varint byte.
+			frame := uint16(tick / 40)
+			if tick%7 == 0 {
+				frame = 0x1ff
+			}
+			if players == 2 {
+				r.Record(frame, uint16(tick%3))
+			} else {
+				r.Record(frame)
+			}
+		}
+		r.Finish(1234)
+
+		data, err := r.ToJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReplayFromJSON(data)
+		if err != nil {
+			t.Fatalf("players %d: %v", players, err)
+		}
+		if got.Ticks != r.Ticks || got.FinalScore != 1234 || got.Seed != 99 || got.Upgrades["fireRate"] != 2 {
+			t.Errorf("players %d: header changed: %+v", players, got)
+		}
+		if !slices.Equal(got.frames, r.frames) {
+			t.Errorf("players %d: frames changed", players)
+		}
+	}
+}
+
+func TestReplayFromJSONRejectsTruncatedFrames(t *testing.T) {
+	r := NewReplay("1.0", 1, "red", nil)
+	for tick := 0; tick < 300; tick++ {
+		r.Record(uint16(tick % 200))
+	}
+	r.Finish(0)
+	packed := encodeFrames(r.frames)
+
+	for _, cut := range []int{1, len(packed) / 2, len(packed) - 1} {
+		r.Frames = base64.StdEncoding.EncodeToString(packed[:cut])
+		if _, err := ReplayFromJSON(replayJSON(t, r)); err == nil {
+			t.Errorf("frames cut to %d of %d bytes were accepted", cut, len(packed))
+		}
+	}
+}
+
+func TestReplayFromJSONRejectsOversizedRuns(t *testing.T) {
+	r := NewReplay("1.0", 1, "red", nil)
+	r.Ticks = 10
+
+	// A single run claiming far more frames than the header allows.
+	packed := binary.AppendUvarint(nil, 1)
+	packed = binary.AppendUvarint(packed, 1<<40)
+	r.Frames = base64.StdEncoding.EncodeToString(packed)
+	if _, err := ReplayFromJSON(replayJSON(t, r)); err == nil || !strings.Contains(err.Error(), "run past") {
+		t.Errorf("oversized run: err = %v", err)
+	}
+
+	for _, bad := range []struct{ ticks, players int }{
+		{-1, 0}, {maxReplayTicks + 1, 0}, {10, 3}, {10, -1},
+	} {
+		r.Ticks, r.Players = bad.ticks, bad.players
+		r.Frames = ""
+		if _, err := ReplayFromJSON(replayJSON(t, r)); err == nil {
+			t.Errorf("%d ticks for %d players was accepted", bad.ticks, bad.players)
+		}
+	}
+}
+
+// replayJSON marshals r as stored, with its Frames field as the test set
+// it rather than re-encoded.
+func replayJSON(t *testing.T, r *Replay) string {
+	t.Helper()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,68 @@
+package systems
+
+import "encoding/json"
+
+// Settings are the player's options, kept between sessions. Fields missing
+// from an older save keep their defaults.
+type Settings struct {
+	MasterVolume float64 `json:"masterVolume"`
+	SFXVolume    float64 `json:"sfxVolume"`
+	SFXEnabled   bool    `json:"sfxEnabled"`
+	ScreenShake  bool    `json:"screenShake"`
+
+	// Bindings lists each input action's keys and gamepad buttons by
+	// name. Actions it leaves out keep their default binding.
+	Bindings map[string][]string `json:"bindings,omitempty"`
+
+	// Touch is the on-screen controls layout; nil keeps the default.
+	Touch *TouchSettings `json:"touch,omitempty"`
+	// Display holds the render and window options; nil keeps the default.
+	Display *DisplaySettings `json:"display,omitempty"`
+}
+
+// DisplaySettings mirrors display.Options field for field.
+type DisplaySettings struct {
+	RenderScale  float64 `json:"renderScale"`
+	IntegerScale bool    `json:"integerScale"`
+	Fullscreen   bool    `json:"fullscreen"`
+}
+
+// TouchSettings mirrors input.TouchLayout field for field so the two
+// convert directly.
+type TouchSettings struct {
+	JoystickX      float64 `json:"joystickX"`
+	JoystickY      float64 `json:"joystickY"`
+	JoystickRadius float64 `json:"joystickRadius"`
+	ShootX         float64 `json:"shootX"`
+	ShootY         float64 `json:"shootY"`
+	ShootRadius    float64 `json:"shootRadius"`
+	Opacity        float64 `json:"opacity"`
+	Floating       bool    `json:"floating"`
+	AutoFire       bool    `json:"autoFire"`
+	LeftHanded     bool    `json:"leftHanded"`
+}
+
+func NewSettings() *Settings {
+	return &Settings{
+		MasterVolume: 0.7,
+		SFXVolume:    0.7,
+		SFXEnabled:   true,
+		ScreenShake:  true,
+	}
+}
+
+func (s *Settings) ToJSON() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func SettingsFromJSON(jsonData string) (*Settings, error) {
+	settings := NewSettings()
+	if err := json.Unmarshal([]byte(jsonData), settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
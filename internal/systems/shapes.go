@@ -0,0 +1,221 @@
+package systems
+
+import "math"
+
+// Shape is a hitbox in field coordinates. Any two shapes can be tested
+// against each other with Overlaps. A pointer to a shape works too, so an
+// entity can hand out a hitbox it keeps without copying it into an
+// interface every call.
+type Shape interface {
+	// Bounds is the smallest Rect around the shape.
+	Bounds() Rect
+}
+
+// Circle is a disc of Radius around Center.
+type Circle struct {
+	Center Vector
+	Radius float64
+}
+
+// Capsule is every point within Radius of the segment from A to B: a
+// stadium, good for long thin things such as laser bolts.
+type Capsule struct {
+	A, B   Vector
+	Radius float64
+}
+
+// Polygon is a convex polygon. Points go round it in either direction.
+type Polygon struct {
+	Points []Vector
+}
+
+func (c Circle) Bounds() Rect {
+	return NewRect(c.Center.X-c.Radius, c.Center.Y-c.Radius, c.Radius*2, c.Radius*2)
+}
+
+func (c Capsule) Bounds() Rect {
+	x, y := min(c.A.X, c.B.X), min(c.A.Y, c.B.Y)
+	return NewRect(x-c.Radius, y-c.Radius,
+		max(c.A.X, c.B.X)-x+c.Radius*2, max(c.A.Y, c.B.Y)-y+c.Radius*2)
+}
+
+func (p Polygon) Bounds() Rect {
+	if len(p.Points) == 0 {
+		return Rect{}
+	}
+	lo, hi := p.Points[0], p.Points[0]
+	for _, v := range p.Points[1:] {
+		lo.X, lo.Y = min(lo.X, v.X), min(lo.Y, v.Y)
+		hi.X, hi.Y = max(hi.X, v.X), max(hi.Y, v.Y)
+	}
+	return NewRect(lo.X, lo.Y, hi.X-lo.X, hi.Y-lo.Y)
+}
+
+func (r Rect) Bounds() Rect {
+	return r
+}
+
+// Polygon is the rectangle as a four-sided polygon.
+func (r Rect) Polygon() Polygon {
+	return Polygon{Points: []Vector{
+		{X: r.X, Y: r.Y},
+		{X: r.MaxX(), Y: r.Y},
+		{X: r.MaxX(), Y: r.MaxY()},
+		{X: r.X, Y: r.MaxY()},
+	}}
+}
+
+// PolygonIn builds a polygon from points given as fractions of r's width
+// and height, turned by angle radians about r's centre.
+func PolygonIn(r Rect, angle float64, fractions ...Vector) Polygon {
+	var p Polygon
+	p.Fit(r, angle, fractions...)
+	return p
+}
+
+// Fit is PolygonIn in place, reusing p's points.
+func (p *Polygon) Fit(r Rect, angle float64, fractions ...Vector) {
+	cx, cy := r.CenterX(), r.Y+r.Height/2
+	sin, cos := math.Sincos(angle)
+	p.Points = p.Points[:0]
+	for _, f := range fractions {
+		x := r.X + f.X*r.Width - cx
+		y := r.Y + f.Y*r.Height - cy
+		p.Points = append(p.Points, Vector{X: cx + x*cos - y*sin, Y: cy + x*sin + y*cos})
+	}
+}
+
+// Overlaps reports whether two shapes touch. Circles and capsules are
+// compared by the distance between their core segments; polygons use the
+// separating axis test.
+func Overlaps(a, b Shape) bool {
+	if !a.Bounds().Intersects(b.Bounds()) {
+		return false
+	}
+
+	ca, aRound := rounded(a)
+	cb, bRound := rounded(b)
+	switch {
+	case aRound && bRound:
+		return segmentDistance(ca.A, ca.B, cb.A, cb.B) <= ca.Radius+cb.Radius
+	case aRound:
+		return capsulePolygon(ca, polygonOf(b))
+	case bRound:
+		return capsulePolygon(cb, polygonOf(a))
+	}
+	return polygonsOverlap(polygonOf(a), polygonOf(b))
+}
+
+// rounded gives a circle or capsule as a capsule; a circle is one whose
+// ends meet.
+func rounded(s Shape) (Capsule, bool) {
+	switch s := s.(type) {
+	case Circle:
+		return Capsule{A: s.Center, B: s.Center, Radius: s.Radius}, true
+	case Capsule:
+		return s, true
+	case *Capsule:
+		return *s, true
+	}
+	return Capsule{}, false
+}
+
+func polygonOf(s Shape) Polygon {
+	switch s := s.(type) {
+	case Polygon:
+		return s
+	case *Polygon:
+		return *s
+	case Rect:
+		return s.Polygon()
+	}
+	return s.Bounds().Polygon()
+}
+
+func capsulePolygon(c Capsule, p Polygon) bool {
+	if p.contains(c.A) {
+		return true
+	}
+	for i, v := range p.Points {
+		w := p.Points[(i+1)%len(p.Points)]
+		if segmentDistance(c.A, c.B, v, w) <= c.Radius {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether pt is inside the polygon or on its edge.
+func (p Polygon) contains(pt Vector) bool {
+	sign := 0.0
+	for i, v := range p.Points {
+		w := p.Points[(i+1)%len(p.Points)]
+		cross := (w.X-v.X)*(pt.Y-v.Y) - (w.Y-v.Y)*(pt.X-v.X)
+		if cross == 0 {
+			continue
+		}
+		if sign != 0 && (cross > 0) != (sign > 0) {
+			return false
+		}
+		sign = cross
+	}
+	return len(p.Points) > 0
+}
+
+// polygonsOverlap looks for an edge normal of either polygon along which
+// their projections are apart.
+func polygonsOverlap(a, b Polygon) bool {
+	for _, p := range [2]Polygon{a, b} {
+		for i, v := range p.Points {
+			w := p.Points[(i+1)%len(p.Points)]
+			axis := Vector{X: v.Y - w.Y, Y: w.X - v.X}
+			aMin, aMax := a.project(axis)
+			bMin, bMax := b.project(axis)
+			if aMax < bMin || bMax < aMin {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p Polygon) project(axis Vector) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range p.Points {
+		d := v.X*axis.X + v.Y*axis.Y
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	return lo, hi
+}
+
+// segmentDistance is the shortest distance between segments pq and rs,
+// zero when they cross.
+func segmentDistance(p, q, r, s Vector) float64 {
+	if segmentsCross(p, q, r, s) {
+		return 0
+	}
+	return min(pointSegmentDistance(p, r, s), pointSegmentDistance(q, r, s),
+		pointSegmentDistance(r, p, q), pointSegmentDistance(s, p, q))
+}
+
+func segmentsCross(p, q, r, s Vector) bool {
+	d1 := orient(r, s, p)
+	d2 := orient(r, s, q)
+	d3 := orient(p, q, r)
+	d4 := orient(p, q, s)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+func orient(a, b, c Vector) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func pointSegmentDistance(pt, a, b Vector) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = max(0, min(1, ((pt.X-a.X)*dx+(pt.Y-a.Y)*dy)/l))
+	}
+	return math.Hypot(pt.X-(a.X+t*dx), pt.Y-(a.Y+t*dy))
+}
@@ -0,0 +1,131 @@
+package systems
+
+import (
+	"math"
+	"testing"
+)
+
+func square(x, y, size float64) Polygon {
+	return NewRect(x, y, size, size).Polygon()
+}
+
+func circle(x, y, r float64) Circle {
+	return Circle{Center: Vector{X: x, Y: y}, Radius: r}
+}
+
+func capsule(ax, ay, bx, by, r float64) Capsule {
+	return Capsule{A: Vector{X: ax, Y: ay}, B: Vector{X: bx, Y: by}, Radius: r}
+}
+
+func triangle(points ...float64) Polygon {
+	p := Polygon{}
+	for i := 0; i < len(points); i += 2 {
+		p.Points = append(p.Points, Vector{X: points[i], Y: points[i+1]})
+	}
+	return p
+}
+
+// Each case is checked both ways round, since Overlaps must not depend on
+// the order of its arguments.
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Shape
+		want bool
+	}{
+		{"circle circle overlapping", circle(0, 0, 5), circle(8, 0, 5), true},
+		{"circle circle touching", circle(0, 0, 5), circle(10, 0, 5), true},
+		{"circle circle apart", circle(0, 0, 5), circle(10.5, 0, 5), false},
+		{"circle inside circle", circle(0, 0, 20), circle(3, 3, 2), true},
+
+		{"circle capsule side", circle(50, 10, 5), capsule(0, 0, 100, 0, 5), true},
+		{"circle capsule touching cap", circle(110, 0, 5), capsule(0, 0, 100, 0, 5), true},
+		{"circle capsule apart", circle(50, 11, 5), capsule(0, 0, 100, 0, 5), false},
+		{"circle inside capsule", circle(50, 0, 1), capsule(0, 0, 100, 0, 5), true},
+
+		{"circle polygon edge", circle(12, 5, 3), square(0, 0, 10), true},
+		{"circle polygon touching edge", circle(13, 5, 3), square(0, 0, 10), true},
+		{"circle polygon past corner", circle(12, 12, 2), square(0, 0, 10), false},
+		{"circle inside polygon", circle(5, 5, 1), square(0, 0, 10), true},
+		{"polygon inside circle", square(-1, -1, 2), circle(0, 0, 10), true},
+
+		{"capsule capsule crossing", capsule(0, 0, 10, 10, 1), capsule(0, 10, 10, 0, 1), true},
+		{"capsule capsule parallel apart", capsule(0, 0, 10, 0, 1), capsule(0, 3, 10, 3, 1), false},
+		{"capsule capsule touching", capsule(0, 0, 10, 0, 1), capsule(0, 2, 10, 2, 1), true},
+		{"capsule capsule end to end", capsule(0, 0, 10, 0, 1), capsule(12, 0, 20, 0, 1), true},
+
+		{"capsule through polygon", capsule(-5, 5, 15, 5, 1), square(0, 0, 10), true},
+		{"capsule inside polygon", capsule(3, 5, 7, 5, 1), square(0, 0, 10), true},
+		{"capsule touching polygon", capsule(11, 0, 11, 10, 1), square(0, 0, 10), true},
+		{"capsule beside polygon", capsule(12, 0, 12, 10, 1), square(0, 0, 10), false},
+
+		{"polygon polygon overlapping", square(0, 0, 10), square(5, 5, 10), true},
+		{"polygon polygon shared edge", square(0, 0, 10), square(10, 0, 10), true},
+		{"polygon polygon shared corner", square(0, 0, 10), square(10, 10, 10), true},
+		{"polygon inside polygon", square(0, 0, 10), square(2, 2, 3), true},
+		{"triangles with overlapping bounds", triangle(0, 0, 10, 0, 0, 10), triangle(10, 10, 10, 4, 4, 10), false},
+		{"rect and polygon", NewRect(0, 0, 10, 10), triangle(9, 9, 20, 9, 9, 20), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Overlaps(tt.a, tt.b); got != tt.want {
+				t.Errorf("Overlaps(a, b) = %v, want %v", got, tt.want)
+			}
+			if got := Overlaps(tt.b, tt.a); got != tt.want {
+				t.Errorf("Overlaps(b, a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// meteorOutline matches the one in package entities: an octagon with its
+// corners cut, inside the sprite's box.
+var meteorOutline = []Vector{
+	{X: 0.3, Y: 0.05}, {X: 0.7, Y: 0.05}, {X: 0.95, Y: 0.3}, {X: 0.95, Y: 0.7},
+	{X: 0.7, Y: 0.95}, {X: 0.3, Y: 0.95}, {X: 0.05, Y: 0.7}, {X: 0.05, Y: 0.3},
+}
+
+func TestMeteorOutlineSkipsBoxCorners(t *testing.T) {
+	box := NewRect(100, 100, 80, 80)
+	outline := PolygonIn(box, 0, meteorOutline...)
+
+	corner := circle(box.X+4, box.Y+4, 3)
+	if !Overlaps(box, corner) {
+		t.Fatal("shot in the corner misses the box")
+	}
+	if Overlaps(outline, corner) {
+		t.Error("shot in the box corner hits the meteor outline")
+	}
+	if !Overlaps(outline, circle(box.CenterX(), box.Y+8, 3)) {
+		t.Error("shot at the top edge misses the meteor outline")
+	}
+}
+
+func TestPolygonInFollowsRotation(t *testing.T) {
+	// A long, flat box, so a quarter turn moves its ends onto new ground.
+	box := NewRect(0, 0, 100, 20)
+	above := circle(box.CenterX(), -30, 3)
+	end := circle(95, 10, 3)
+
+	flat := PolygonIn(box, 0, meteorOutline...)
+	if Overlaps(flat, above) || !Overlaps(flat, end) {
+		t.Fatal("unturned outline is not where the box is")
+	}
+
+	upright := PolygonIn(box, math.Pi/2, meteorOutline...)
+	if !Overlaps(upright, above) {
+		t.Error("turned outline misses a shot where its end now is")
+	}
+	if Overlaps(upright, end) {
+		t.Error("turned outline still hit where its end used to be")
+	}
+
+	// A half turn maps the symmetric outline onto itself.
+	half := PolygonIn(box, math.Pi, meteorOutline...)
+	for i, p := range half.Points {
+		q := flat.Points[(i+4)%len(flat.Points)]
+		if math.Abs(p.X-q.X) > 1e-9 || math.Abs(p.Y-q.Y) > 1e-9 {
+			t.Fatalf("half turn point %d at %+v, want %+v", i, p, q)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package systems
+
+import (
+	"math"
+	"slices"
+)
+
+// SpatialHash is a uniform grid that narrows collision checks down to
+// things sharing a cell. It is meant to be cleared and refilled every
+// tick; cells and buffers are kept between fills, so a steady game does
+// not allocate.
+type SpatialHash struct {
+	cellSize float64
+	cells    map[cell][]int
+	// filled lists the cells holding ids, for Clear.
+	filled []cell
+	// stamps marks each id with the last query that returned it, so an id
+	// spanning several cells comes back once.
+	stamps []uint32
+	query  uint32
+}
+
+type cell struct {
+	x, y int
+}
+
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    make(map[cell][]int),
+	}
+}
+
+// Clear empties the grid.
+func (h *SpatialHash) Clear() {
+	for _, c := range h.filled {
+		h.cells[c] = h.cells[c][:0]
+	}
+	h.filled = h.filled[:0]
+}
+
+// Insert files id under every cell bounds touches. Ids are indices, such
+// as into the slice being tested, and should be small.
+func (h *SpatialHash) Insert(id int, bounds Rect) {
+	if id >= len(h.stamps) {
+		h.stamps = append(h.stamps, make([]uint32, id+1-len(h.stamps))...)
+	}
+	x0, y0, x1, y1 := h.span(bounds)
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			c := cell{x, y}
+			ids := h.cells[c]
+			if len(ids) == 0 {
+				h.filled = append(h.filled, c)
+			}
+			h.cells[c] = append(ids, id)
+		}
+	}
+}
+
+// Query appends to out, in ascending order and once each, the ids sharing
+// a cell with bounds. Every id whose bounds intersect it is among them.
+func (h *SpatialHash) Query(bounds Rect, out []int) []int {
+	h.query++
+	if h.query == 0 {
+		clear(h.stamps)
+		h.query = 1
+	}
+
+	start := len(out)
+	x0, y0, x1, y1 := h.span(bounds)
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			for _, id := range h.cells[cell{x, y}] {
+				if h.stamps[id] != h.query {
+					h.stamps[id] = h.query
+					out = append(out, id)
+				}
+			}
+		}
+	}
+	slices.Sort(out[start:])
+	return out
+}
+
+func (h *SpatialHash) span(r Rect) (x0, y0, x1, y1 int) {
+	return h.coord(r.X), h.coord(r.Y), h.coord(r.MaxX()), h.coord(r.MaxY())
+}
+
+func (h *SpatialHash) coord(v float64) int {
+	return int(math.Floor(v / h.cellSize))
+}
@@ -0,0 +1,143 @@
+package systems
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Query returns whatever shares a cell with the area, so with 10px cells
+// the expectations below follow from which cells each entry covers.
+func TestSpatialHashQuery(t *testing.T) {
+	h := NewSpatialHash(10)
+	h.Insert(0, NewRect(0, 0, 5, 5))     // one cell
+	h.Insert(1, NewRect(5, 5, 20, 20))   // spans nine cells
+	h.Insert(2, NewRect(-15, -15, 5, 5)) // negative cells
+	h.Insert(3, NewRect(10, 0, 0, 0))    // a point on a cell boundary
+	h.Insert(4, NewRect(29, 29, 1, 1))   // ends exactly on a boundary
+
+	tests := []struct {
+		name   string
+		bounds Rect
+		want   []int
+	}{
+		{"empty area", NewRect(100, 100, 5, 5), nil},
+		{"one cell", NewRect(12, 12, 1, 1), []int{1}},
+		{"cell shared by two", NewRect(1, 1, 1, 1), []int{0, 1}},
+		{"spanning entry once", NewRect(0, 0, 29, 29), []int{0, 1, 3, 4}},
+		{"negative coordinates", NewRect(-12, -12, 1, 1), []int{2}},
+		{"reaching a boundary", NewRect(8, 2, 2, 2), []int{0, 1, 3}},
+		{"point on a boundary", NewRect(10, 0, 0, 0), []int{1, 3}},
+		{"touching a corner", NewRect(30, 30, 3, 3), []int{4}},
+		{"straddling zero", NewRect(-1, -1, 2, 2), []int{0, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.Query(tt.bounds, nil)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Query(%+v) = %v, want %v", tt.bounds, got, tt.want)
+			}
+		})
+	}
+
+	h.Clear()
+	if got := h.Query(NewRect(-100, -100, 200, 200), nil); len(got) != 0 {
+		t.Errorf("after Clear, Query = %v", got)
+	}
+}
+
+// Every id whose bounds intersect the query must come back, in order and
+// once, whatever the cell size.
+func TestSpatialHashFindsEveryIntersection(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	rects := make([]Rect, 200)
+	for i := range rects {
+		rects[i] = NewRect(rng.Float64()*900-50, rng.Float64()*700-50, rng.Float64()*120, rng.Float64()*120)
+	}
+
+	for _, size := range []float64{1, 16, 96, 1000} {
+		h := NewSpatialHash(size)
+		for i, r := range rects {
+			h.Insert(i, r)
+		}
+		for q := 0; q < 100; q++ {
+			area := NewRect(rng.Float64()*800, rng.Float64()*600, rng.Float64()*200, rng.Float64()*200)
+			got := h.Query(area, nil)
+			if !slices.IsSorted(got) || len(slices.Compact(slices.Clone(got))) != len(got) {
+				t.Fatalf("cell %v: Query not sorted and unique: %v", size, got)
+			}
+			for i, r := range rects {
+				if r.Intersects(area) && !slices.Contains(got, i) {
+					t.Fatalf("cell %v: Query(%+v) missed %d at %+v", size, area, i, r)
+				}
+			}
+		}
+	}
+}
+
+// benchScene places meteor outlines and laser capsules across the field,
+// as on a late wave.
+func benchScene(meteors, lasers int) ([]Shape, []Shape) {
+	rng := rand.New(rand.NewSource(1))
+	outline := []Vector{
+		{X: 0.3, Y: 0.05}, {X: 0.7, Y: 0.05}, {X: 0.95, Y: 0.3}, {X: 0.95, Y: 0.7},
+		{X: 0.7, Y: 0.95}, {X: 0.3, Y: 0.95}, {X: 0.05, Y: 0.7}, {X: 0.05, Y: 0.3},
+	}
+	ms := make([]Shape, meteors)
+	for i := range ms {
+		box := NewRect(rng.Float64()*800, rng.Float64()*600, 90, 80)
+		ms[i] = PolygonIn(box, rng.Float64()*6, outline...)
+	}
+	ls := make([]Shape, lasers)
+	for i := range ls {
+		x, y := rng.Float64()*800, rng.Float64()*600
+		ls[i] = Capsule{A: Vector{X: x, Y: y + 5}, B: Vector{X: x, Y: y + 50}, Radius: 5}
+	}
+	return ms, ls
+}
+
+var benchSizes = [][2]int{{20, 10}, {60, 40}, {120, 80}}
+
+// BenchmarkLaserMeteorBruteForce tests every meteor against every laser,
+// as the collision pass did before the grid.
+func BenchmarkLaserMeteorBruteForce(b *testing.B) {
+	for _, n := range benchSizes {
+		ms, ls := benchScene(n[0], n[1])
+		b.Run(fmt.Sprintf("%dx%d", n[0], n[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				for _, m := range ms {
+					for _, l := range ls {
+						Overlaps(m, l)
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLaserMeteorGrid refills a grid with the lasers and tests each
+// meteor against its neighbours only, as the collision pass does now.
+func BenchmarkLaserMeteorGrid(b *testing.B) {
+	for _, n := range benchSizes {
+		ms, ls := benchScene(n[0], n[1])
+		b.Run(fmt.Sprintf("%dx%d", n[0], n[1]), func(b *testing.B) {
+			h := NewSpatialHash(96)
+			var near []int
+			b.ReportAllocs()
+			for range b.N {
+				h.Clear()
+				for j, l := range ls {
+					h.Insert(j, l.Bounds())
+				}
+				for _, m := range ms {
+					near = h.Query(m.Bounds(), near[:0])
+					for _, j := range near {
+						Overlaps(m, ls[j])
+					}
+				}
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 )
 
 type Storage interface {
@@ -16,6 +17,17 @@ type Storage interface {
 	LoadLeaderboard() (string, error)
 	SaveProgress(progress *PlayerProgress) error
 	LoadProgress() (*PlayerProgress, error)
+	SaveReplay(data string) error
+	LoadReplay() (string, error)
+	SaveRun(data string) error
+	LoadRun() (string, error)
+	ClearRun() error
+	// Best times are kept per mode and difficulty, which changes boss
+	// health and lives.
+	SaveBestTime(mode, difficulty string, best time.Duration) error
+	LoadBestTime(mode, difficulty string) time.Duration
+	SaveSettings(settings *Settings) error
+	LoadSettings() (*Settings, error)
 }
 
 type localStorage struct {
@@ -94,3 +106,76 @@ func (s *localStorage) LoadProgress() (*PlayerProgress, error) {
 	}
 	return progress, nil
 }
+
+func (s *localStorage) SaveReplay(jsonData string) error {
+	path := filepath.Join(s.dataDir, "replay.json")
+	return os.WriteFile(path, []byte(jsonData), 0644)
+}
+
+func (s *localStorage) LoadReplay() (string, error) {
+	path := filepath.Join(s.dataDir, "replay.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+func (s *localStorage) SaveRun(jsonData string) error {
+	path := filepath.Join(s.dataDir, "run.json")
+	return os.WriteFile(path, []byte(jsonData), 0644)
+}
+
+func (s *localStorage) LoadRun() (string, error) {
+	path := filepath.Join(s.dataDir, "run.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+func (s *localStorage) ClearRun() error {
+	err := os.Remove(filepath.Join(s.dataDir, "run.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *localStorage) SaveBestTime(mode, difficulty string, best time.Duration) error {
+	path := filepath.Join(s.dataDir, "besttime_"+mode+"_"+difficulty+".txt")
+	return os.WriteFile(path, []byte(strconv.FormatInt(best.Milliseconds(), 10)), 0644)
+}
+
+func (s *localStorage) LoadBestTime(mode, difficulty string) time.Duration {
+	path := filepath.Join(s.dataDir, "besttime_"+mode+"_"+difficulty+".txt")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	ms, _ := strconv.ParseInt(string(data), 10, 64)
+	return time.Duration(ms) * time.Millisecond
+}
+
+func (s *localStorage) SaveSettings(settings *Settings) error {
+	jsonData, err := settings.ToJSON()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dataDir, "settings.json")
+	return os.WriteFile(path, []byte(jsonData), 0644)
+}
+
+func (s *localStorage) LoadSettings() (*Settings, error) {
+	path := filepath.Join(s.dataDir, "settings.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewSettings(), nil
+	}
+	settings, err := SettingsFromJSON(string(data))
+	if err != nil {
+		return NewSettings(), nil
+	}
+	return settings, nil
+}
@@ -0,0 +1,112 @@
+package systems
+
+import "time"
+
+// memoryStorage keeps everything in process memory. It backs headless runs
+// so they never touch the player's real save data.
+type memoryStorage struct {
+	highScore   int
+	leaderboard string
+	progress    string
+	replay      string
+	run         string
+	settings    string
+	bestTimes   map[string]time.Duration
+}
+
+func NewMemoryStorage() Storage {
+	return &memoryStorage{bestTimes: make(map[string]time.Duration)}
+}
+
+func (s *memoryStorage) SaveHighScore(score int) error {
+	s.highScore = score
+	return nil
+}
+
+func (s *memoryStorage) LoadHighScore() int {
+	return s.highScore
+}
+
+func (s *memoryStorage) SaveLeaderboard(jsonData string) error {
+	s.leaderboard = jsonData
+	return nil
+}
+
+func (s *memoryStorage) LoadLeaderboard() (string, error) {
+	if s.leaderboard == "" {
+		return "{\"entries\":[]}", nil
+	}
+	return s.leaderboard, nil
+}
+
+func (s *memoryStorage) SaveProgress(progress *PlayerProgress) error {
+	jsonData, err := progress.ToJSON()
+	if err != nil {
+		return err
+	}
+	s.progress = jsonData
+	return nil
+}
+
+func (s *memoryStorage) LoadProgress() (*PlayerProgress, error) {
+	if s.progress == "" {
+		return NewPlayerProgress(), nil
+	}
+	progress, err := PlayerProgressFromJSON(s.progress)
+	if err != nil {
+		return NewPlayerProgress(), nil
+	}
+	return progress, nil
+}
+
+func (s *memoryStorage) SaveReplay(jsonData string) error {
+	s.replay = jsonData
+	return nil
+}
+
+func (s *memoryStorage) LoadReplay() (string, error) {
+	return s.replay, nil
+}
+
+func (s *memoryStorage) SaveRun(jsonData string) error {
+	s.run = jsonData
+	return nil
+}
+
+func (s *memoryStorage) LoadRun() (string, error) {
+	return s.run, nil
+}
+
+func (s *memoryStorage) ClearRun() error {
+	s.run = ""
+	return nil
+}
+
+func (s *memoryStorage) SaveBestTime(mode, difficulty string, best time.Duration) error {
+	s.bestTimes[mode+"/"+difficulty] = best
+	return nil
+}
+
+func (s *memoryStorage) LoadBestTime(mode, difficulty string) time.Duration {
+	return s.bestTimes[mode+"/"+difficulty]
+}
+
+func (s *memoryStorage) SaveSettings(settings *Settings) error {
+	jsonData, err := settings.ToJSON()
+	if err != nil {
+		return err
+	}
+	s.settings = jsonData
+	return nil
+}
+
+func (s *memoryStorage) LoadSettings() (*Settings, error) {
+	if s.settings == "" {
+		return NewSettings(), nil
+	}
+	settings, err := SettingsFromJSON(s.settings)
+	if err != nil {
+		return NewSettings(), nil
+	}
+	return settings, nil
+}
@@ -3,7 +3,11 @@
 
 package systems
 
-import "syscall/js"
+import (
+	"strconv"
+	"syscall/js"
+	"time"
+)
 
 type Storage interface {
 	SaveHighScore(score int) error
@@ -12,6 +16,17 @@ type Storage interface {
 	LoadLeaderboard() (string, error)
 	SaveProgress(progress *PlayerProgress) error
 	LoadProgress() (*PlayerProgress, error)
+	SaveReplay(data string) error
+	LoadReplay() (string, error)
+	SaveRun(data string) error
+	LoadRun() (string, error)
+	ClearRun() error
+	// Best times are kept per mode and difficulty, which changes boss
+	// health and lives.
+	SaveBestTime(mode, difficulty string, best time.Duration) error
+	LoadBestTime(mode, difficulty string) time.Duration
+	SaveSettings(settings *Settings) error
+	LoadSettings() (*Settings, error)
 }
 
 type webStorage struct {
@@ -87,3 +102,69 @@ func (s *webStorage) LoadProgress() (*PlayerProgress, error) {
 	}
 	return progress, nil
 }
+
+func (s *webStorage) SaveReplay(jsonData string) error {
+	s.localStorage.Call("setItem", "spaceGoReplay", jsonData)
+	return nil
+}
+
+func (s *webStorage) LoadReplay() (string, error) {
+	val := s.localStorage.Call("getItem", "spaceGoReplay")
+	if val.IsNull() {
+		return "", nil
+	}
+	return val.String(), nil
+}
+
+func (s *webStorage) SaveRun(jsonData string) error {
+	s.localStorage.Call("setItem", "spaceGoRun", jsonData)
+	return nil
+}
+
+func (s *webStorage) LoadRun() (string, error) {
+	val := s.localStorage.Call("getItem", "spaceGoRun")
+	if val.IsNull() {
+		return "", nil
+	}
+	return val.String(), nil
+}
+
+func (s *webStorage) ClearRun() error {
+	s.localStorage.Call("removeItem", "spaceGoRun")
+	return nil
+}
+
+func (s *webStorage) SaveBestTime(mode, difficulty string, best time.Duration) error {
+	s.localStorage.Call("setItem", "spaceGoBestTime_"+mode+"_"+difficulty, strconv.FormatInt(best.Milliseconds(), 10))
+	return nil
+}
+
+func (s *webStorage) LoadBestTime(mode, difficulty string) time.Duration {
+	val := s.localStorage.Call("getItem", "spaceGoBestTime_"+mode+"_"+difficulty)
+	if val.IsNull() {
+		return 0
+	}
+	ms, _ := strconv.ParseInt(val.String(), 10, 64)
+	return time.Duration(ms) * time.Millisecond
+}
+
+func (s *webStorage) SaveSettings(settings *Settings) error {
+	jsonData, err := settings.ToJSON()
+	if err != nil {
+		return err
+	}
+	s.localStorage.Call("setItem", "spaceGoSettings", jsonData)
+	return nil
+}
+
+func (s *webStorage) LoadSettings() (*Settings, error) {
+	val := s.localStorage.Call("getItem", "spaceGoSettings")
+	if val.IsNull() {
+		return NewSettings(), nil
+	}
+	settings, err := SettingsFromJSON(val.String())
+	if err != nil {
+		return NewSettings(), nil
+	}
+	return settings, nil
+}
@@ -18,6 +18,12 @@ func NewTimer(d time.Duration) *Timer {
 	}
 }
 
+// SetDuration changes the target without losing progress, so a spawn
+// interval can follow the wave it is in.
+func (t *Timer) SetDuration(d time.Duration) {
+	t.targetTicks = int(d.Milliseconds()) * ebiten.TPS() / 2500
+}
+
 func (t *Timer) Update() {
 	if t.currentTicks < t.targetTicks {
 		t.currentTicks++
@@ -42,3 +48,22 @@ func (t *Timer) Progress() float64 {
 func (t *Timer) CurrentTicks() int {
 	return t.currentTicks
 }
+
+// TicksFor converts a wall-clock duration into update ticks at the current TPS.
+func TicksFor(d time.Duration) int {
+	return int(d.Seconds() * float64(ebiten.TPS()))
+}
+
+// TimerState is the saved form of a Timer.
+type TimerState struct {
+	Current int `json:"current"`
+	Target  int `json:"target"`
+}
+
+func (t *Timer) State() TimerState {
+	return TimerState{Current: t.currentTicks, Target: t.targetTicks}
+}
+
+func RestoreTimer(s TimerState) *Timer {
+	return &Timer{currentTicks: s.Current, targetTicks: s.Target}
+}
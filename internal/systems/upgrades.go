@@ -0,0 +1,115 @@
+package systems
+
+import "time"
+
+// UpgradeEffect is how a run applies an upgrade.
+type UpgradeEffect int
+
+const (
+	// EffectPowerUpDuration makes the PowerUp last BonusPerLevel longer
+	// per level.
+	EffectPowerUpDuration UpgradeEffect = iota
+	EffectCoinMagnet
+	EffectDoubleCoins
+	EffectStartBoost
+)
+
+// Upgrade is one item of the shop. ID is the key stored in saves and
+// replays; Icon is a key of assets.IconMap.
+type Upgrade struct {
+	ID            string
+	Name          string
+	Icon          string
+	Description   string
+	MaxLevel      int
+	Costs         []int
+	Effect        UpgradeEffect
+	PowerUp       string
+	BonusPerLevel time.Duration
+}
+
+// IsPerk reports whether the upgrade is a one-off purchase rather than a
+// levelled power-up.
+func (u Upgrade) IsPerk() bool {
+	return u.Effect != EffectPowerUpDuration
+}
+
+// CostAt is the price of the level after level, or 0 once maxed.
+func (u Upgrade) CostAt(level int) int {
+	if level < 0 || level >= u.MaxLevel || level >= len(u.Costs) {
+		return 0
+	}
+	return u.Costs[level]
+}
+
+func (u Upgrade) Bonus(level int) time.Duration {
+	return time.Duration(min(level, u.MaxLevel)) * u.BonusPerLevel
+}
+
+var powerUpCosts = []int{25, 50, 100, 200, 400}
+
+// Upgrades is every upgrade in shop order.
+var Upgrades = []Upgrade{
+	{ID: "superpower", Name: "Super Shot", Icon: "superpower", MaxLevel: 5, Costs: powerUpCosts, PowerUp: "superShot", BonusPerLevel: 2 * time.Second},
+	{ID: "shield", Name: "Shield", Icon: "shield", MaxLevel: 5, Costs: powerUpCosts, PowerUp: "shield", BonusPerLevel: 2 * time.Second},
+	{ID: "slowmotion", Name: "Slow Motion", Icon: "clock", MaxLevel: 5, Costs: powerUpCosts, PowerUp: "slowMotion", BonusPerLevel: 2 * time.Second},
+	{ID: "laser", Name: "Laser Beam", Icon: "laser", MaxLevel: 5, Costs: powerUpCosts, PowerUp: "laser", BonusPerLevel: 2 * time.Second},
+	{ID: "nuke", Name: "Nuke", Icon: "nuke", MaxLevel: 5, Costs: powerUpCosts, PowerUp: "nuke", BonusPerLevel: 2 * time.Second},
+	{ID: "multiplier", Name: "Multiplier", Icon: "multiplier", MaxLevel: 5, Costs: powerUpCosts, PowerUp: "multiplier", BonusPerLevel: 2 * time.Second},
+	{ID: "coinmagnet", Name: "Coin Magnet", Icon: "coin", Description: "Pulls nearby coins in", MaxLevel: 1, Costs: []int{500}, Effect: EffectCoinMagnet},
+	{ID: "doublecoins", Name: "Double Coins", Icon: "coin", Description: "Coins are worth 2x", MaxLevel: 1, Costs: []int{250}, Effect: EffectDoubleCoins},
+	{ID: "startboost", Name: "Start with Boost", Icon: "superpower", Description: "Runs start with Super Shot", MaxLevel: 1, Costs: []int{250}, Effect: EffectStartBoost},
+}
+
+func UpgradeByID(id string) (Upgrade, bool) {
+	for _, u := range Upgrades {
+		if u.ID == id {
+			return u, true
+		}
+	}
+	return Upgrade{}, false
+}
+
+// UpgradeForPowerUp finds the duration upgrade of a power-up, by its
+// waves.json name.
+func UpgradeForPowerUp(powerUp string) (Upgrade, bool) {
+	for _, u := range Upgrades {
+		if u.Effect == EffectPowerUpDuration && u.PowerUp == powerUp {
+			return u, true
+		}
+	}
+	return Upgrade{}, false
+}
+
+// DefaultSkin is free and owned by every player.
+const DefaultSkin = "gray"
+
+// Skin is a ship sprite sold in the shop. ID is also the key of
+// assets.SkinMap.
+type Skin struct {
+	ID   string
+	Name string
+	Cost int
+}
+
+// Skins is every skin in shop order.
+var Skins = []Skin{
+	{ID: DefaultSkin, Name: "Gray", Cost: 0},
+	{ID: "green", Name: "Green", Cost: 50},
+	{ID: "yellow", Name: "Yellow", Cost: 50},
+	{ID: "pink", Name: "Pink", Cost: 50},
+	{ID: "red", Name: "Red", Cost: 50},
+	{ID: "purple", Name: "Purple", Cost: 50},
+	{ID: "black", Name: "Black", Cost: 100},
+	{ID: "gold", Name: "Gold", Cost: 250},
+	{ID: "white", Name: "White", Cost: 50},
+}
+
+func SkinByID(id string) (Skin, bool) {
+	for _, s := range Skins {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Skin{}, false
+}
@@ -0,0 +1,260 @@
+package systems
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+//go:embed waves.json
+var defaultWaves []byte
+
+// WaveTable holds the tunable difficulty curve. Each list is ordered by
+// FromWave and an entry applies until the next one starts.
+type WaveTable struct {
+	Meteors      []MeteorWave  `json:"meteors"`
+	PowerUps     []PowerUpWave `json:"powerUps"`
+	BossPowerUps []PowerUpWave `json:"bossPowerUps"`
+	checksum     string
+	fromOverride bool
+}
+
+type MeteorWave struct {
+	FromWave int `json:"fromWave"`
+	// Count meteors spawn per interval at FromWave, plus one more every
+	// ExtraEvery waves after it (0 disables growth).
+	Count        int     `json:"count"`
+	ExtraEvery   int     `json:"extraEvery"`
+	Speed        float64 `json:"speed"`
+	SpeedPerWave float64 `json:"speedPerWave"`
+	SpawnMs      int     `json:"spawnMs"`
+	Mix          struct {
+		Normal    float64 `json:"normal"`
+		Ice       float64 `json:"ice"`
+		Explosive float64 `json:"explosive"`
+	} `json:"mix"`
+}
+
+type PowerUpWave struct {
+	FromWave int                `json:"fromWave"`
+	SpawnMs  int                `json:"spawnMs"`
+	Weights  map[string]float64 `json:"weights"`
+	sorted   []PowerUpWeight
+	total    float64
+}
+
+type PowerUpWeight struct {
+	Name   string
+	Weight float64
+}
+
+// MeteorMix is the chance of each special meteor type; the rest are normal.
+type MeteorMix struct {
+	Ice       float64
+	Explosive float64
+}
+
+// MeteorSettings is a MeteorWave resolved for one wave.
+type MeteorSettings struct {
+	Count           int
+	SpeedMultiplier float64
+	SpawnInterval   time.Duration
+	Mix             MeteorMix
+}
+
+// LoadWaveTable returns the embedded table, or the on-disk override when
+// one exists. isPowerUp reports whether a weight name is a known power-up.
+// If the override is invalid the embedded table is returned with the error
+// so the game can still start.
+func LoadWaveTable(isPowerUp func(string) bool) (*WaveTable, error) {
+	table, err := parseWaveTable(defaultWaves, isPowerUp)
+	if err != nil {
+		return nil, fmt.Errorf("embedded waves.json: %w", err)
+	}
+
+	data, path, ok := readWaveOverride()
+	if !ok {
+		return table, nil
+	}
+
+	override, err := parseWaveTable(data, isPowerUp)
+	if err != nil {
+		return table, fmt.Errorf("%s: %w", path, err)
+	}
+	override.fromOverride = true
+	return override, nil
+}
+
+func parseWaveTable(data []byte, isPowerUp func(string) bool) (*WaveTable, error) {
+	var t WaveTable
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	if err := t.validate(isPowerUp); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	t.checksum = hex.EncodeToString(sum[:8])
+	return &t, nil
+}
+
+func (t *WaveTable) validate(isPowerUp func(string) bool) error {
+	if len(t.Meteors) == 0 || t.Meteors[0].FromWave != 1 {
+		return fmt.Errorf("meteors must start at wave 1")
+	}
+	for i, w := range t.Meteors {
+		if i > 0 && w.FromWave <= t.Meteors[i-1].FromWave {
+			return fmt.Errorf("meteors[%d]: fromWave must increase", i)
+		}
+		if w.Count < 1 || w.ExtraEvery < 0 {
+			return fmt.Errorf("meteors[%d]: count must be at least 1 and extraEvery not negative", i)
+		}
+		if w.Speed <= 0 || w.SpeedPerWave < 0 {
+			return fmt.Errorf("meteors[%d]: speed must be positive", i)
+		}
+		if w.SpawnMs <= 0 {
+			return fmt.Errorf("meteors[%d]: spawnMs must be positive", i)
+		}
+		if w.Mix.Normal < 0 || w.Mix.Ice < 0 || w.Mix.Explosive < 0 || w.Mix.Normal+w.Mix.Ice+w.Mix.Explosive <= 0 {
+			return fmt.Errorf("meteors[%d]: mix weights must be non-negative and not all zero", i)
+		}
+	}
+
+	if err := validatePowerUpWaves("powerUps", t.PowerUps, isPowerUp); err != nil {
+		return err
+	}
+	return validatePowerUpWaves("bossPowerUps", t.BossPowerUps, isPowerUp)
+}
+
+func validatePowerUpWaves(field string, waves []PowerUpWave, isPowerUp func(string) bool) error {
+	if len(waves) == 0 || waves[0].FromWave != 1 {
+		return fmt.Errorf("%s must start at wave 1", field)
+	}
+	for i := range waves {
+		w := &waves[i]
+		if i > 0 && w.FromWave <= waves[i-1].FromWave {
+			return fmt.Errorf("%s[%d]: fromWave must increase", field, i)
+		}
+		if w.SpawnMs <= 0 {
+			return fmt.Errorf("%s[%d]: spawnMs must be positive", field, i)
+		}
+
+		// Sorted so that a weighted pick does not depend on map order.
+		w.sorted = w.sorted[:0]
+		w.total = 0
+		for name, weight := range w.Weights {
+			if !isPowerUp(name) {
+				return fmt.Errorf("%s[%d]: unknown power-up %q", field, i, name)
+			}
+			if weight < 0 {
+				return fmt.Errorf("%s[%d]: weight for %q is negative", field, i, name)
+			}
+			w.sorted = append(w.sorted, PowerUpWeight{Name: name, Weight: weight})
+			w.total += weight
+		}
+		if w.total <= 0 {
+			return fmt.Errorf("%s[%d]: weights must not all be zero", field, i)
+		}
+		sort.Slice(w.sorted, func(a, b int) bool { return w.sorted[a].Name < w.sorted[b].Name })
+	}
+	return nil
+}
+
+// Checksum identifies the table contents. Replays store it because the
+// same inputs only reproduce a run under the same table.
+func (t *WaveTable) Checksum() string {
+	return t.checksum
+}
+
+func (t *WaveTable) IsOverride() bool {
+	return t.fromOverride
+}
+
+func (t *WaveTable) MeteorsAt(wave int) MeteorSettings {
+	w := t.Meteors[0]
+	for _, candidate := range t.Meteors {
+		if candidate.FromWave <= wave {
+			w = candidate
+		}
+	}
+
+	past := max(0, wave-w.FromWave)
+	count := w.Count
+	if w.ExtraEvery > 0 {
+		count += past / w.ExtraEvery
+	}
+
+	total := w.Mix.Normal + w.Mix.Ice + w.Mix.Explosive
+	return MeteorSettings{
+		Count:           count,
+		SpeedMultiplier: w.Speed + float64(past)*w.SpeedPerWave,
+		SpawnInterval:   time.Duration(w.SpawnMs) * time.Millisecond,
+		Mix: MeteorMix{
+			Ice:       w.Mix.Ice / total,
+			Explosive: w.Mix.Explosive / total,
+		},
+	}
+}
+
+func (t *WaveTable) PowerUpsAt(wave int) *PowerUpWave {
+	return powerUpWaveAt(t.PowerUps, wave)
+}
+
+func (t *WaveTable) BossPowerUpsAt(wave int) *PowerUpWave {
+	return powerUpWaveAt(t.BossPowerUps, wave)
+}
+
+func powerUpWaveAt(waves []PowerUpWave, wave int) *PowerUpWave {
+	w := &waves[0]
+	for i := range waves {
+		if waves[i].FromWave <= wave {
+			w = &waves[i]
+		}
+	}
+	return w
+}
+
+func (w *PowerUpWave) SpawnInterval() time.Duration {
+	return time.Duration(w.SpawnMs) * time.Millisecond
+}
+
+// Without returns a copy of the wave that never picks name. If name is the
+// only power-up with any weight the wave is returned unchanged.
+func (w *PowerUpWave) Without(name string) *PowerUpWave {
+	filtered := *w
+	filtered.sorted = make([]PowerUpWeight, 0, len(w.sorted))
+	filtered.total = 0
+	for _, entry := range w.sorted {
+		if entry.Name != name {
+			filtered.sorted = append(filtered.sorted, entry)
+			filtered.total += entry.Weight
+		}
+	}
+	if filtered.total <= 0 {
+		return w
+	}
+	return &filtered
+}
+
+// Pick rolls one power-up name using the wave's weights.
+func (w *PowerUpWave) Pick(rng *rand.Rand) string {
+	roll := rng.Float64() * w.total
+	for _, entry := range w.sorted {
+		if roll < entry.Weight {
+			return entry.Name
+		}
+		roll -= entry.Weight
+	}
+	for i := len(w.sorted) - 1; i > 0; i-- {
+		if w.sorted[i].Weight > 0 {
+			return w.sorted[i].Name
+		}
+	}
+	return w.sorted[0].Name
+}
@@ -0,0 +1,24 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package systems
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// readWaveOverride looks for waves.json next to the save files so the
+// curve can be tuned without rebuilding.
+func readWaveOverride() ([]byte, string, bool) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, "", false
+	}
+	path := filepath.Join(homeDir, ".go-meteor", "waves.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", false
+	}
+	return data, path, true
+}
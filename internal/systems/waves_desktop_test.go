@@ -0,0 +1,54 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package systems
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withWaveOverride points the home directory at a fresh one holding data
+// as .go-meteor/waves.json.
+func withWaveOverride(t *testing.T, data string) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir := filepath.Join(home, ".go-meteor")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "waves.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInvalidWaveOverrideFallsBack(t *testing.T) {
+	withWaveOverride(t, `{"meteors": [{"fromWave": 3, "count": 1, "speed": 1, "spawnMs": 500}]}`)
+
+	table, err := LoadWaveTable(testPowerUps)
+	if err == nil {
+		t.Fatal("invalid override accepted")
+	}
+	if table == nil || table.IsOverride() {
+		t.Fatal("invalid override did not fall back to the embedded table")
+	}
+	embedded, _ := parseWaveTable(defaultWaves, testPowerUps)
+	if table.Checksum() != embedded.Checksum() {
+		t.Error("fallback table is not the embedded one")
+	}
+}
+
+func TestValidWaveOverrideIsUsed(t *testing.T) {
+	withWaveOverride(t, string(defaultWaves)+"\n")
+
+	table, err := LoadWaveTable(testPowerUps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedded, _ := parseWaveTable(defaultWaves, testPowerUps)
+	if !table.IsOverride() || table.Checksum() == embedded.Checksum() {
+		t.Error("override not loaded")
+	}
+}
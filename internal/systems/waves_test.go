@@ -0,0 +1,163 @@
+package systems
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPowerUps accepts the power-up names the game knows.
+func testPowerUps(name string) bool {
+	return slices.Contains([]string{
+		"superShot", "heart", "shield", "slowMotion", "laser", "nuke", "extraLife", "multiplier",
+	}, name)
+}
+
+// The embedded table replaced a hard-coded curve; these are its formulas,
+// so the table can be checked against them wave by wave.
+func oldMeteorCount(wave int) int {
+	return max(1, 1+(wave-1)/5)
+}
+
+func oldSpeedMultiplier(wave int) float64 {
+	if wave >= 20 {
+		return 1 + float64(wave-20)*0.15
+	}
+	return 1
+}
+
+func TestEmbeddedWaveTableMatchesOldCurve(t *testing.T) {
+	table, err := parseWaveTable(defaultWaves, testPowerUps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, wave := range []int{1, 4, 5, 6, 11, 19, 20, 21, 22, 26, 40} {
+		got := table.MeteorsAt(wave)
+		if got.Count != oldMeteorCount(wave) {
+			t.Errorf("wave %d: %d meteors, want %d", wave, got.Count, oldMeteorCount(wave))
+		}
+		if math.Abs(got.SpeedMultiplier-oldSpeedMultiplier(wave)) > 1e-9 {
+			t.Errorf("wave %d: speed %v, want %v", wave, got.SpeedMultiplier, oldSpeedMultiplier(wave))
+		}
+		if got.SpawnInterval != time.Second {
+			t.Errorf("wave %d: meteors every %v, want 1s", wave, got.SpawnInterval)
+		}
+		if math.Abs(got.Mix.Ice-0.08) > 1e-9 || math.Abs(got.Mix.Explosive-0.06) > 1e-9 {
+			t.Errorf("wave %d: mix %+v, want 8%% ice and 6%% explosive", wave, got.Mix)
+		}
+	}
+
+	// The old rolls, as chances: before wave 5 a fair pick of four; from
+	// wave 5 half extra lives, then 60% laser, nuke or multiplier.
+	chances := []struct {
+		wave  int
+		boss  bool
+		items map[string]float64
+	}{
+		{1, false, map[string]float64{"superShot": 0.25, "shield": 0.25, "slowMotion": 0.25, "multiplier": 0.25}},
+		{5, false, map[string]float64{
+			"extraLife": 0.5, "laser": 0.15, "nuke": 0.075, "multiplier": 0.125,
+			"superShot": 0.05, "shield": 0.05, "slowMotion": 0.05,
+		}},
+		{1, true, map[string]float64{"superShot": 0.25, "heart": 0.25, "shield": 0.25, "slowMotion": 0.25}},
+		{5, true, map[string]float64{
+			"laser": 0.3, "nuke": 0.3, "superShot": 0.1, "heart": 0.1, "shield": 0.1, "slowMotion": 0.1,
+		}},
+	}
+	for _, c := range chances {
+		w, every := table.PowerUpsAt(c.wave), 20*time.Second
+		if c.boss {
+			w, every = table.BossPowerUpsAt(c.wave), 8*time.Second
+		}
+		if w.SpawnInterval() != every {
+			t.Errorf("wave %d boss=%v: power-ups every %v, want %v", c.wave, c.boss, w.SpawnInterval(), every)
+		}
+		if len(w.sorted) != len(c.items) {
+			t.Errorf("wave %d boss=%v: %d power-ups, want %d", c.wave, c.boss, len(w.sorted), len(c.items))
+		}
+		for _, entry := range w.sorted {
+			if got := entry.Weight / w.total; math.Abs(got-c.items[entry.Name]) > 1e-9 {
+				t.Errorf("wave %d boss=%v: %s chance %v, want %v", c.wave, c.boss, entry.Name, got, c.items[entry.Name])
+			}
+		}
+	}
+}
+
+func TestWaveTableRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		spoil func(w *WaveTable)
+		want  string
+	}{
+		{"no meteors", func(w *WaveTable) { w.Meteors = nil }, "meteors must start at wave 1"},
+		{"meteors start late", func(w *WaveTable) { w.Meteors[0].FromWave = 2 }, "meteors must start at wave 1"},
+		{"meteors out of order", func(w *WaveTable) { w.Meteors[1].FromWave = 1 }, "meteors[1]: fromWave"},
+		{"no meteors per wave", func(w *WaveTable) { w.Meteors[0].Count = 0 }, "count"},
+		{"negative growth", func(w *WaveTable) { w.Meteors[0].ExtraEvery = -1 }, "extraEvery"},
+		{"no speed", func(w *WaveTable) { w.Meteors[0].Speed = 0 }, "speed"},
+		{"no meteor interval", func(w *WaveTable) { w.Meteors[1].SpawnMs = 0 }, "meteors[1]: spawnMs"},
+		{"empty mix", func(w *WaveTable) {
+			w.Meteors[0].Mix.Normal, w.Meteors[0].Mix.Ice, w.Meteors[0].Mix.Explosive = 0, 0, 0
+		}, "mix"},
+		{"no power-ups", func(w *WaveTable) { w.PowerUps = nil }, "powerUps must start at wave 1"},
+		{"no boss power-ups", func(w *WaveTable) { w.BossPowerUps = nil }, "bossPowerUps must start at wave 1"},
+		{"unknown power-up", func(w *WaveTable) { w.PowerUps[1].Weights["jetpack"] = 10 }, `unknown power-up "jetpack"`},
+		{"negative weight", func(w *WaveTable) { w.BossPowerUps[0].Weights["shield"] = -1 }, "negative"},
+		{"zero weights", func(w *WaveTable) { w.PowerUps[0].Weights = map[string]float64{"shield": 0} }, "not all be zero"},
+		{"no power-up interval", func(w *WaveTable) { w.BossPowerUps[1].SpawnMs = 0 }, "bossPowerUps[1]: spawnMs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := parseWaveTable(defaultWaves, testPowerUps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.spoil(table)
+			data, err := json.Marshal(table)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = parseWaveTable(data, testPowerUps)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaveTableRejectsMalformedJSON(t *testing.T) {
+	if _, err := parseWaveTable([]byte(`{"meteors": [`), testPowerUps); err == nil {
+		t.Error("truncated table accepted")
+	}
+}
+
+func TestWaveChecksumFollowsContents(t *testing.T) {
+	table, err := parseWaveTable(defaultWaves, testPowerUps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := parseWaveTable(defaultWaves, testPowerUps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Checksum() == "" || table.Checksum() != again.Checksum() {
+		t.Fatalf("checksums %q and %q for the same table", table.Checksum(), again.Checksum())
+	}
+
+	table.Meteors[1].SpeedPerWave = 0.2
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tuned, err := parseWaveTable(data, testPowerUps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tuned.Checksum() == again.Checksum() {
+		t.Error("retuned table kept the embedded checksum")
+	}
+}
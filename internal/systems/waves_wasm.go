@@ -0,0 +1,9 @@
+//go:build js && wasm
+// +build js,wasm
+
+package systems
+
+// The browser build has no file system to override from.
+func readWaveOverride() ([]byte, string, bool) {
+	return nil, "", false
+}
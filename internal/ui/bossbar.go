@@ -2,7 +2,6 @@ package ui
 
 import (
 	"fmt"
-	"go-meteor/internal/config"
 	assets "go-meteor/src/pkg"
 	"image/color"
 
@@ -29,15 +28,16 @@ func (bb *BossBar) Hide() {
 	bb.visible = false
 }
 
-func (bb *BossBar) Draw(screen *ebiten.Image, currentHealth, maxHealth int) {
+// Draw draws the bar barWidth wide at y, centred across screen, which is
+// the view in a run. marks are the health fractions where the boss changes
+// phase; those already passed are dimmed.
+func (bb *BossBar) Draw(screen *ebiten.Image, y, barWidth float32, currentHealth, maxHealth int, marks []float64) {
 	if !bb.visible {
 		return
 	}
 
-	barWidth := float32(600)
 	barHeight := float32(30)
-	x := float32(config.ScreenWidth-int(barWidth)) / 2
-	y := float32(30)
+	x := (float32(screen.Bounds().Dx()) - barWidth) / 2
 
 	bgColor := color.RGBA{50, 50, 50, 200}
 	vector.DrawFilledRect(screen, x-2, y-2, barWidth+4, barHeight+4, bgColor, false)
@@ -62,6 +62,15 @@ func (bb *BossBar) Draw(screen *ebiten.Image, currentHealth, maxHealth int) {
 
 	vector.DrawFilledRect(screen, x, y, currentWidth, barHeight, healthColor, false)
 
+	for _, mark := range marks {
+		markX := x + barWidth*float32(mark)
+		markColor := color.RGBA{255, 255, 255, 230}
+		if float32(mark) >= healthPercent {
+			markColor = color.RGBA{120, 120, 120, 160}
+		}
+		vector.StrokeLine(screen, markX, y-4, markX, y+barHeight+4, 3, markColor, false)
+	}
+
 	borderColor := color.RGBA{200, 200, 200, 255}
 	vector.StrokeRect(screen, x, y, barWidth, barHeight, 2, borderColor, false)
 
@@ -0,0 +1,302 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
+	assets "go-meteor/src/pkg"
+)
+
+const (
+	controlsStartY     = 104
+	controlsRowHeight  = 22
+	controlsActionX    = 60
+	controlsColumnX    = 320
+	controlsColumnStep = 160
+	controlsColumns    = input.MaxKeysPerAction + 1
+
+	controlsRowReset = int(input.ActionCount)
+	controlsRowBack  = controlsRowReset + 1
+	controlsRows     = controlsRowBack + 1
+
+	// controlsCaptureTicks is how long the screen waits for a key, so
+	// any key, Escape included, can be bound.
+	controlsCaptureTicks = 300
+	controlsMessageTicks = 120
+)
+
+var (
+	colorControlsHeader   = color.RGBA{150, 150, 200, 255}
+	colorControlsCapture  = color.RGBA{100, 200, 255, 255}
+	colorControlsConflict = color.RGBA{255, 100, 100, 255}
+)
+
+// controlsScreen is the rebinding page of the settings. Every change is
+// applied at once through input.SetBindings.
+type controlsScreen struct {
+	row, col  int
+	capturing int
+	message   string
+	msgTimer  int
+	cooldown  int
+	closed    bool
+}
+
+func (c *controlsScreen) open() {
+	c.row, c.col = 0, 0
+	c.capturing = 0
+	c.msgTimer = 0
+	c.cooldown = 10
+	c.closed = false
+}
+
+func (c *controlsScreen) Draw(screen *ebiten.Image) {
+	screen.DrawImage(settingsOverlay, nil)
+
+	title := "CONTROLS"
+	drawText(screen, title, assets.FontUi, (config.ScreenWidth-measureText(title, assets.FontUi))/2, 60, colorSettingsWhite)
+
+	headerY := controlsStartY - controlsRowHeight
+	for col, label := range []string{"Key 1", "Key 2", "Gamepad"} {
+		drawText(screen, label, assets.FontSmall, controlsColumnX+col*controlsColumnStep, headerY, colorControlsHeader)
+	}
+
+	bindings := input.CurrentBindings()
+	for a := input.Action(0); a < input.ActionCount; a++ {
+		y := controlsRowY(int(a))
+		drawText(screen, a.String(), assets.FontSmall, controlsActionX, y, colorSettingsGray)
+		for col := 0; col < controlsColumns; col++ {
+			drawText(screen, c.cellLabel(bindings, a, col), assets.FontSmall, controlsColumnX+col*controlsColumnStep, y, c.cellColor(int(a), col))
+		}
+	}
+
+	c.drawOption(screen, controlsRowReset, "RESET TO DEFAULTS")
+	c.drawOption(screen, controlsRowBack, "BACK")
+
+	hint := fmt.Sprintf("%s: Rebind  |  DEL: Clear  |  %s: Back", input.KeyLabel(input.ActionConfirm), input.KeyLabel(input.ActionBack))
+	msgColor := color.Color(colorSettingsGray)
+	if c.capturing > 0 {
+		hint = fmt.Sprintf("Press a key for %s... (%d)", input.Action(c.row), c.capturing/60+1)
+		if c.col == input.MaxKeysPerAction {
+			hint = fmt.Sprintf("Press a gamepad button for %s... (%d)", input.Action(c.row), c.capturing/60+1)
+		}
+		msgColor = colorControlsCapture
+	} else if c.msgTimer > 0 {
+		hint = c.message
+		msgColor = colorControlsConflict
+	}
+	drawText(screen, hint, assets.FontSmall, (config.ScreenWidth-measureText(hint, assets.FontSmall))/2, config.ScreenHeight-25, msgColor)
+}
+
+func (c *controlsScreen) drawOption(screen *ebiten.Image, row int, label string) {
+	optionColor := colorSettingsGray
+	if c.row == row {
+		optionColor = colorSettingsGold
+	}
+	drawText(screen, label, assets.FontSmall, (config.ScreenWidth-measureText(label, assets.FontSmall))/2, controlsRowY(row), optionColor)
+}
+
+func controlsRowY(row int) int {
+	y := controlsStartY + controlsRowHeight*row
+	if row >= controlsRowReset {
+		y += 20 + (row-controlsRowReset)*6
+	}
+	return y
+}
+
+func (c *controlsScreen) cellLabel(b input.Bindings, a input.Action, col int) string {
+	if c.capturing > 0 && c.row == int(a) && c.col == col {
+		return "..."
+	}
+	if col == input.MaxKeysPerAction {
+		if len(b[a].Buttons) == 0 {
+			return "-"
+		}
+		return input.ButtonName(b[a].Buttons[0])
+	}
+	if col >= len(b[a].Keys) {
+		return "-"
+	}
+	return b[a].Keys[col].String()
+}
+
+func (c *controlsScreen) cellColor(row, col int) color.Color {
+	if c.row != row || c.col != col {
+		return colorSettingsWhite
+	}
+	if c.capturing > 0 {
+		return colorControlsCapture
+	}
+	return colorSettingsGold
+}
+
+func (c *controlsScreen) Update() {
+	if c.msgTimer > 0 {
+		c.msgTimer--
+	}
+	if c.capturing > 0 {
+		c.updateCapture()
+		return
+	}
+	if c.cooldown > 0 {
+		c.cooldown--
+		return
+	}
+
+	c.handleKeyboardInput()
+	c.handleMouseAndTouch()
+}
+
+func (c *controlsScreen) handleKeyboardInput() {
+	switch {
+	case input.ActionJustPressed(input.ActionBack):
+		c.closed = true
+	case input.ActionJustPressed(input.ActionMenuUp):
+		c.row = (c.row + controlsRows - 1) % controlsRows
+	case input.ActionJustPressed(input.ActionMenuDown):
+		c.row = (c.row + 1) % controlsRows
+	case input.ActionJustPressed(input.ActionMenuLeft):
+		c.col = (c.col + controlsColumns - 1) % controlsColumns
+	case input.ActionJustPressed(input.ActionMenuRight):
+		c.col = (c.col + 1) % controlsColumns
+	case input.ActionJustPressed(input.ActionConfirm):
+		c.activate()
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete) || inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		c.clear()
+	}
+}
+
+func (c *controlsScreen) handleMouseAndTouch() {
+	handleClick := func(x, y int) {
+		for row := 0; row < controlsRows; row++ {
+			rowY := controlsRowY(row)
+			if y < rowY-controlsRowHeight+6 || y > rowY+6 {
+				continue
+			}
+			if row >= controlsRowReset {
+				if x >= config.ScreenWidth/4 && x <= config.ScreenWidth*3/4 {
+					c.row = row
+					c.activate()
+				}
+				return
+			}
+			col := (x - controlsColumnX) / controlsColumnStep
+			if x >= controlsColumnX && col < controlsColumns {
+				c.row, c.col = row, col
+				c.activate()
+			}
+			return
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		handleClick(display.CursorPosition())
+	}
+	if touchIDs := inpututil.AppendJustPressedTouchIDs(nil); len(touchIDs) > 0 {
+		handleClick(display.TouchPosition(touchIDs[0]))
+	}
+}
+
+func (c *controlsScreen) activate() {
+	switch c.row {
+	case controlsRowReset:
+		input.SetBindings(input.DefaultBindings())
+		c.showMessage("Controls reset to defaults")
+	case controlsRowBack:
+		c.closed = true
+	default:
+		if c.col == input.MaxKeysPerAction && input.Action(c.row).Group() == input.GroupPartner {
+			c.showMessage("P2's gamepad uses the same buttons as P1")
+			return
+		}
+		c.capturing = controlsCaptureTicks
+	}
+	c.cooldown = 10
+}
+
+func (c *controlsScreen) updateCapture() {
+	c.capturing--
+	if c.capturing == 0 {
+		c.cooldown = 10
+		return
+	}
+
+	b := input.CurrentBindings()
+	a := input.Action(c.row)
+
+	if c.col == input.MaxKeysPerAction {
+		button, ok := input.JustPressedButton()
+		if !ok {
+			return
+		}
+		if other, taken := b.ButtonConflict(a, button); taken {
+			c.showMessage(fmt.Sprintf("%s is already used by %s", input.ButtonName(button), other))
+		} else {
+			b.SetButton(a, button)
+			input.SetBindings(b)
+		}
+		c.finishCapture()
+		return
+	}
+
+	keys := inpututil.AppendJustPressedKeys(nil)
+	if len(keys) == 0 {
+		return
+	}
+	key := keys[0]
+	if other, taken := b.Conflict(a, key); taken {
+		c.showMessage(fmt.Sprintf("%s is already used by %s", key, other))
+	} else if c.boundElsewhere(b, a, key) {
+		c.showMessage(fmt.Sprintf("%s is already bound to %s", key, a))
+	} else {
+		b.SetKey(a, c.col, key)
+		input.SetBindings(b)
+	}
+	c.finishCapture()
+}
+
+// boundElsewhere reports whether a's other key slot already holds key.
+func (c *controlsScreen) boundElsewhere(b input.Bindings, a input.Action, key ebiten.Key) bool {
+	for slot, k := range b[a].Keys {
+		if slot != c.col && k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *controlsScreen) finishCapture() {
+	c.capturing = 0
+	c.cooldown = 10
+}
+
+// clear unbinds the selected slot. The last key of an action is kept so
+// it can always be triggered from the keyboard.
+func (c *controlsScreen) clear() {
+	if c.row >= controlsRowReset {
+		return
+	}
+	b := input.CurrentBindings()
+	a := input.Action(c.row)
+	if c.col == input.MaxKeysPerAction {
+		b.ClearButton(a)
+	} else if c.col < len(b[a].Keys) {
+		if len(b[a].Keys) == 1 {
+			c.showMessage(fmt.Sprintf("%s needs at least one key", a))
+			return
+		}
+		b.ClearKey(a, c.col)
+	}
+	input.SetBindings(b)
+}
+
+func (c *controlsScreen) showMessage(msg string) {
+	c.message = msg
+	c.msgTimer = controlsMessageTicks
+}
@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"go-meteor/internal/config"
+	"image/color"
+)
+
+var difficultyColors = map[config.Difficulty]color.RGBA{
+	config.DifficultyEasy:      {100, 255, 100, 255},
+	config.DifficultyNormal:    {150, 200, 255, 255},
+	config.DifficultyHard:      {255, 150, 100, 255},
+	config.DifficultyNightmare: {255, 80, 80, 255},
+}
+
+func DifficultyColor(d config.Difficulty) color.Color {
+	if c, ok := difficultyColors[d]; ok {
+		return c
+	}
+	return color.White
+}
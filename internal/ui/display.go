@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
+	assets "go-meteor/src/pkg"
+)
+
+const (
+	displayRowRenderScale = iota
+	displayRowIntegerScale
+	displayRowFullscreen
+	displayRowBack
+	displayRows
+
+	displayStartY     = 160
+	displayRowHeight  = 50
+	displayScaleStep  = 0.25
+	displayHintBottom = 25
+)
+
+// displayScreen is the settings page for rendering and window options.
+// Every change is applied at once through display.SetOptions.
+type displayScreen struct {
+	row      int
+	cooldown int
+	closed   bool
+}
+
+func (d *displayScreen) open() {
+	d.row = 0
+	d.cooldown = 10
+	d.closed = false
+}
+
+// rows lists the rows shown; Fullscreen is left out where the platform
+// doesn't offer it.
+func (d *displayScreen) rows() []int {
+	if display.FullscreenSupported {
+		return []int{displayRowRenderScale, displayRowIntegerScale, displayRowFullscreen, displayRowBack}
+	}
+	return []int{displayRowRenderScale, displayRowIntegerScale, displayRowBack}
+}
+
+func (d *displayScreen) Draw(screen *ebiten.Image) {
+	screen.DrawImage(settingsOverlay, nil)
+
+	title := "DISPLAY"
+	drawText(screen, title, assets.FontUi, (config.ScreenWidth-measureText(title, assets.FontUi))/2, 80, colorSettingsWhite)
+
+	opts := display.CurrentOptions()
+	for i, row := range d.rows() {
+		var label string
+		switch row {
+		case displayRowRenderScale:
+			label = fmt.Sprintf("Render Scale: %.0f%%", opts.RenderScale*100)
+		case displayRowIntegerScale:
+			label = "Integer Scaling: " + onOff(opts.IntegerScale)
+		case displayRowFullscreen:
+			label = "Fullscreen: " + onOff(opts.Fullscreen)
+		case displayRowBack:
+			label = "BACK"
+		}
+		optionColor := colorSettingsGray
+		if d.row == i {
+			optionColor = colorSettingsGold
+		}
+		drawText(screen, label, assets.FontUi, (config.ScreenWidth-measureText(label, assets.FontUi))/2, displayStartY+displayRowHeight*i, optionColor)
+	}
+
+	hint := fmt.Sprintf("%s/%s: Adjust  |  %s: Back", input.KeyLabel(input.ActionMenuLeft), input.KeyLabel(input.ActionMenuRight), input.KeyLabel(input.ActionBack))
+	drawText(screen, hint, assets.FontSmall, (config.ScreenWidth-measureText(hint, assets.FontSmall))/2, config.ScreenHeight-displayHintBottom, colorSettingsGray)
+}
+
+func (d *displayScreen) Update() {
+	if d.cooldown > 0 {
+		d.cooldown--
+		return
+	}
+
+	rows := len(d.rows())
+	switch {
+	case input.ActionJustPressed(input.ActionBack):
+		d.closed = true
+	case input.ActionJustPressed(input.ActionMenuUp):
+		d.row = (d.row + rows - 1) % rows
+	case input.ActionJustPressed(input.ActionMenuDown):
+		d.row = (d.row + 1) % rows
+	case input.ActionJustPressed(input.ActionMenuLeft):
+		d.adjust(-1)
+	case input.ActionJustPressed(input.ActionMenuRight):
+		d.adjust(1)
+	case input.ActionJustPressed(input.ActionConfirm):
+		d.adjust(1)
+	}
+
+	handleClick := func(x, y int) {
+		if x < config.ScreenWidth/4 || x > config.ScreenWidth*3/4 {
+			return
+		}
+		for i := 0; i < rows; i++ {
+			rowY := displayStartY + displayRowHeight*i
+			if y >= rowY-30 && y <= rowY+10 {
+				d.row = i
+				d.adjust(1)
+				return
+			}
+		}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		handleClick(display.CursorPosition())
+	}
+	if touchIDs := inpututil.AppendJustPressedTouchIDs(nil); len(touchIDs) > 0 {
+		handleClick(display.TouchPosition(touchIDs[0]))
+	}
+}
+
+// adjust steps the render scale in direction dir, wrapping round, or
+// flips the selected toggle.
+func (d *displayScreen) adjust(dir float64) {
+	opts := display.CurrentOptions()
+	switch d.rows()[d.row] {
+	case displayRowRenderScale:
+		opts.RenderScale += dir * displayScaleStep
+		if opts.RenderScale > 1 {
+			opts.RenderScale = display.MinRenderScale
+		} else if opts.RenderScale < display.MinRenderScale {
+			opts.RenderScale = 1
+		}
+	case displayRowIntegerScale:
+		opts.IntegerScale = !opts.IntegerScale
+	case displayRowFullscreen:
+		opts.Fullscreen = !opts.Fullscreen
+	case displayRowBack:
+		d.closed = true
+		return
+	}
+	display.SetOptions(opts)
+	d.cooldown = 10
+}
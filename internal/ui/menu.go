@@ -3,6 +3,8 @@ package ui
 import (
 	"fmt"
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
 	assets "go-meteor/src/pkg"
 	"image/color"
 
@@ -30,6 +32,11 @@ type Menu struct {
 	cooldown       int
 	highScore      int
 	lastScore      int
+	difficulty     config.Difficulty
+	mode           config.GameMode
+	dailyInfo      string
+	continueEntry  *menuEntry
+	replayEntry    *menuEntry
 	settingsButton *IconButton
 	shopButton     *IconButton
 }
@@ -39,8 +46,19 @@ type IconButton struct {
 	icon       *ebiten.Image
 }
 
+// menuEntry is an optional text option below the start prompt.
+type menuEntry struct {
+	label   string
+	key     ebiten.Key
+	visible bool
+	chosen  bool
+}
+
 func NewMenu() *Menu {
 	return &Menu{
+		difficulty:    config.DifficultyNormal,
+		continueEntry: &menuEntry{label: "C: Continue run", key: ebiten.KeyC},
+		replayEntry:   &menuEntry{label: "R: Watch last replay", key: ebiten.KeyR},
 		settingsButton: &IconButton{
 			x:    config.ScreenWidth - 50,
 			y:    10,
@@ -61,6 +79,9 @@ func (m *Menu) Draw(screen *ebiten.Image) {
 	m.drawTitle(screen)
 	m.drawScores(screen)
 	m.drawInstructions(screen)
+	m.drawMode(screen)
+	m.drawDifficulty(screen)
+	m.drawEntries(screen)
 	m.drawCredit(screen)
 	m.drawButtons(screen)
 }
@@ -83,28 +104,132 @@ func (m *Menu) drawScores(screen *ebiten.Image) {
 	highScoreText := "High Score: " + fmt.Sprintf("%d", m.highScore)
 	highScoreBounds := text.BoundString(assets.FontSmall, highScoreText)
 	highScoreX := (config.ScreenWidth - highScoreBounds.Dx()) / 2
-	text.Draw(screen, highScoreText, assets.FontSmall, highScoreX, 510, colorMenuGold)
+	text.Draw(screen, highScoreText, assets.FontSmall, highScoreX, 532, colorMenuGold)
 
 	if m.lastScore > 0 {
 		lastScoreText := "Last Score: " + fmt.Sprintf("%d", m.lastScore)
 		lastScoreBounds := text.BoundString(assets.FontSmall, lastScoreText)
 		lastScoreX := (config.ScreenWidth - lastScoreBounds.Dx()) / 2
-		text.Draw(screen, lastScoreText, assets.FontSmall, lastScoreX, 535, colorMenuPurple)
+		text.Draw(screen, lastScoreText, assets.FontSmall, lastScoreX, 555, colorMenuPurple)
 	}
 }
 
 func (m *Menu) drawInstructions(screen *ebiten.Image) {
-	instructionText := "Press ENTER to start"
+	instructionText := fmt.Sprintf("Press %s to start", input.KeyLabel(input.ActionConfirm))
 	instructionBounds := text.BoundString(assets.FontUi, instructionText)
 	instructionX := (config.ScreenWidth - instructionBounds.Dx()) / 2
 	text.Draw(screen, instructionText, assets.FontUi, instructionX, 400, colorMenuWhite)
 }
 
+const (
+	menuModeY        = 430
+	menuDifficultyY  = 454
+	menuEntryStartY  = 482
+	menuEntrySpacing = 22
+)
+
+func (m *Menu) modeLabel() string {
+	return fmt.Sprintf("^ Mode: %s v", m.mode)
+}
+
+func (m *Menu) difficultyLabel() string {
+	return fmt.Sprintf("< Difficulty: %s >", m.difficulty)
+}
+
+// isOnSelector reports whether a point is on a centered selector line.
+func isOnSelector(label string, baseline, px, py int) bool {
+	bounds := text.BoundString(assets.FontSmall, label)
+	x := (config.ScreenWidth - bounds.Dx()) / 2
+	y := baseline - bounds.Dy()
+	return px >= x && px <= x+bounds.Dx() && py >= y && py <= baseline+4
+}
+
+func drawSelector(screen *ebiten.Image, label string, baseline int, clr color.Color) {
+	bounds := text.BoundString(assets.FontSmall, label)
+	x := (config.ScreenWidth - bounds.Dx()) / 2
+	text.Draw(screen, label, assets.FontSmall, x, baseline, clr)
+}
+
+func (m *Menu) drawMode(screen *ebiten.Image) {
+	drawSelector(screen, m.modeLabel(), menuModeY, colorMenuWhite)
+}
+
+// drawDifficulty shows today's modifiers instead in Daily Challenge, which
+// is always played on Normal.
+func (m *Menu) drawDifficulty(screen *ebiten.Image) {
+	if m.mode == config.ModeDaily {
+		drawSelector(screen, m.dailyInfo, menuDifficultyY, colorMenuGold)
+		return
+	}
+	drawSelector(screen, m.difficultyLabel(), menuDifficultyY, DifficultyColor(m.difficulty))
+}
+
+func (m *Menu) cycleMode(step int) {
+	count := int(config.ModeCount)
+	m.mode = config.GameMode((int(m.mode) + step + count) % count)
+}
+
+// selectorAt cycles the mode or difficulty if (px, py) is on one of them.
+func (m *Menu) selectorAt(px, py int) bool {
+	if isOnSelector(m.modeLabel(), menuModeY, px, py) {
+		m.cycleMode(1)
+		return true
+	}
+	if m.mode != config.ModeDaily && isOnSelector(m.difficultyLabel(), menuDifficultyY, px, py) {
+		m.cycleDifficulty(1)
+		return true
+	}
+	return false
+}
+
+func (m *Menu) cycleDifficulty(step int) {
+	count := int(config.DifficultyCount)
+	m.difficulty = config.Difficulty((int(m.difficulty) + step + count) % count)
+}
+
+func (m *Menu) visibleEntries() []*menuEntry {
+	entries := make([]*menuEntry, 0, 2)
+	for _, e := range []*menuEntry{m.continueEntry, m.replayEntry} {
+		if e.visible {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func (m *Menu) entryBounds(index int, e *menuEntry) (x, y, w, h int) {
+	bounds := text.BoundString(assets.FontSmall, e.label)
+	baseline := menuEntryStartY + index*menuEntrySpacing
+	return (config.ScreenWidth - bounds.Dx()) / 2, baseline - bounds.Dy(), bounds.Dx(), bounds.Dy()
+}
+
+func (m *Menu) entryAt(px, py int) *menuEntry {
+	for i, e := range m.visibleEntries() {
+		x, y, w, h := m.entryBounds(i, e)
+		if px >= x && px <= x+w && py >= y && py <= y+h {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *Menu) drawEntries(screen *ebiten.Image) {
+	hovered := m.entryAt(display.CursorPosition())
+	for i, e := range m.visibleEntries() {
+		var entryColor color.Color = colorMenuPurple
+		if e == hovered {
+			entryColor = colorMenuGold
+		}
+		x, _, _, _ := m.entryBounds(i, e)
+		text.Draw(screen, e.label, assets.FontSmall, x, menuEntryStartY+i*menuEntrySpacing, entryColor)
+	}
+}
+
 func (m *Menu) drawCredit(screen *ebiten.Image) {
 	creditText := "Luuan11"
 	creditBounds := text.BoundString(assets.FontSmall, creditText)
 	creditX := (config.ScreenWidth - creditBounds.Dx()) / 2
-	text.Draw(screen, creditText, assets.FontSmall, creditX, 565, colorMenuCredit)
+	text.Draw(screen, creditText, assets.FontSmall, creditX, 580, colorMenuCredit)
 }
 
 func (m *Menu) drawButtons(screen *ebiten.Image) {
@@ -140,7 +265,7 @@ func (m *Menu) drawShopButton(screen *ebiten.Image) {
 }
 
 func (m *Menu) isButtonHovered(btn *IconButton, width float64) bool {
-	x, y := ebiten.CursorPosition()
+	x, y := display.CursorPosition()
 	return float64(x) >= btn.x && float64(x) <= btn.x+width &&
 		float64(y) >= btn.y && float64(y) <= btn.y+btn.size
 }
@@ -151,10 +276,30 @@ func (m *Menu) Update() {
 		return
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+	if input.ActionJustPressed(input.ActionConfirm) {
 		m.readyToPlay = true
 	}
 
+	if input.ActionJustPressed(input.ActionMenuLeft) {
+		m.cycleDifficulty(-1)
+	}
+	if input.ActionJustPressed(input.ActionMenuRight) {
+		m.cycleDifficulty(1)
+	}
+	if input.ActionJustPressed(input.ActionMenuUp) {
+		m.cycleMode(-1)
+	}
+	if input.ActionJustPressed(input.ActionMenuDown) {
+		m.cycleMode(1)
+	}
+
+	for _, e := range m.visibleEntries() {
+		if inpututil.IsKeyJustPressed(e.key) {
+			e.chosen = true
+			return
+		}
+	}
+
 	m.handleMouseInput()
 	m.handleTouchInput()
 }
@@ -174,6 +319,15 @@ func (m *Menu) handleMouseInput() {
 		return
 	}
 
+	if e := m.entryAt(display.CursorPosition()); e != nil {
+		e.chosen = true
+		return
+	}
+
+	if m.selectorAt(display.CursorPosition()) {
+		return
+	}
+
 	m.readyToPlay = true
 }
 
@@ -184,7 +338,7 @@ func (m *Menu) handleTouchInput() {
 	}
 
 	for _, id := range touchIDs {
-		x, y := ebiten.TouchPosition(id)
+		x, y := display.TouchPosition(id)
 
 		if m.isTouchOnButton(m.settingsButton, x, y, m.settingsButton.size) {
 			m.openSettings = true
@@ -195,6 +349,15 @@ func (m *Menu) handleTouchInput() {
 			m.openShop = true
 			return
 		}
+
+		if e := m.entryAt(x, y); e != nil {
+			e.chosen = true
+			return
+		}
+
+		if m.selectorAt(x, y) {
+			return
+		}
 	}
 
 	m.readyToPlay = true
@@ -225,8 +388,26 @@ func (m *Menu) ShouldOpenShop() bool {
 	return false
 }
 
+func (m *Menu) ShouldContinueRun() bool {
+	return m.continueEntry.take()
+}
+
+func (m *Menu) ShouldWatchReplay() bool {
+	return m.replayEntry.take()
+}
+
+func (e *menuEntry) take() bool {
+	if e.chosen {
+		e.chosen = false
+		return true
+	}
+	return false
+}
+
 func (m *Menu) Reset() {
 	m.readyToPlay = false
+	m.continueEntry.chosen = false
+	m.replayEntry.chosen = false
 	m.openSettings = false
 	m.openShop = false
 	m.cooldown = menuCooldownFrames
@@ -240,3 +421,27 @@ func (m *Menu) SetScores(highScore, lastScore int) {
 	m.highScore = highScore
 	m.lastScore = lastScore
 }
+
+func (m *Menu) Difficulty() config.Difficulty {
+	return m.difficulty
+}
+
+func (m *Menu) SetDifficulty(d config.Difficulty) {
+	m.difficulty = d
+}
+
+func (m *Menu) Mode() config.GameMode {
+	return m.mode
+}
+
+func (m *Menu) SetDailyInfo(info string) {
+	m.dailyInfo = info
+}
+
+func (m *Menu) SetContinueAvailable(available bool) {
+	m.continueEntry.visible = available
+}
+
+func (m *Menu) SetReplayAvailable(available bool) {
+	m.replayEntry.visible = available
+}
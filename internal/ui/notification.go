@@ -26,12 +26,20 @@ type Notification struct {
 	timer     *systems.Timer
 	notifType NotificationType
 	active    bool
+	y         int
 }
 
 func NewNotification() *Notification {
+	return NewNotificationAt(80)
+}
+
+// NewNotificationAt is a notification drawn at baseline y instead of near
+// the top of the screen.
+func NewNotificationAt(y int) *Notification {
 	return &Notification{
 		timer:  systems.NewTimer(3 * time.Second),
 		active: false,
+		y:      y,
 	}
 }
 
@@ -82,7 +90,7 @@ func (n *Notification) Draw(screen *ebiten.Image) {
 
 	bounds := text.BoundString(assets.FontUi, n.message)
 	x := (config.ScreenWidth - bounds.Dx()) / 2
-	text.Draw(screen, n.message, assets.FontUi, x, 80, textColor)
+	text.Draw(screen, n.message, assets.FontUi, x, n.y, textColor)
 }
 
 func (n *Notification) IsActive() bool {
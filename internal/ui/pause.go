@@ -3,6 +3,8 @@ package ui
 import (
 	"fmt"
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
 	assets "go-meteor/src/pkg"
 	"image/color"
 	"time"
@@ -150,7 +152,7 @@ func (p *PauseMenu) drawButtons(screen *ebiten.Image) {
 }
 
 func (p *PauseMenu) drawIconButton(screen *ebiten.Image, btn *IconButton) {
-	x, y := ebiten.CursorPosition()
+	x, y := display.CursorPosition()
 	op := &ebiten.DrawImageOptions{}
 	if p.isButtonHovered(btn, float64(x), float64(y)) {
 		op.ColorScale.ScaleWithColor(colorPauseGold)
@@ -171,18 +173,18 @@ func (p *PauseMenu) Update() int {
 }
 
 func (p *PauseMenu) handleKeyboardInput() int {
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
+	if input.ActionJustPressed(input.ActionMenuDown) {
 		p.selectedOption = (p.selectedOption + 1) % len(p.options)
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
+	if input.ActionJustPressed(input.ActionMenuUp) {
 		p.selectedOption--
 		if p.selectedOption < 0 {
 			p.selectedOption = len(p.options) - 1
 		}
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+	if input.ActionJustPressed(input.ActionConfirm) {
 		return p.selectedOption
 	}
 
@@ -191,11 +193,11 @@ func (p *PauseMenu) handleKeyboardInput() int {
 
 func (p *PauseMenu) handleMouseAndTouch() int {
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		return p.handleClick(ebiten.CursorPosition())
+		return p.handleClick(display.CursorPosition())
 	}
 
 	if touchIDs := inpututil.AppendJustPressedTouchIDs(nil); len(touchIDs) > 0 {
-		return p.handleClick(ebiten.TouchPosition(touchIDs[0]))
+		return p.handleClick(display.TouchPosition(touchIDs[0]))
 	}
 
 	return PauseActionNone
@@ -3,6 +3,8 @@ package ui
 import (
 	"fmt"
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
 	assets "go-meteor/src/pkg"
 	"image/color"
 
@@ -15,11 +17,16 @@ const (
 	settingsOptionMasterVolume = 0
 	settingsOptionSFXVolume    = 1
 	settingsOptionToggleSFX    = 2
-	settingsOptionBack         = 3
-	settingsTotalOptions       = 4
-
-	settingsStartY     = 150
-	settingsSpacing    = 55
+	settingsOptionScreenShake  = 3
+	settingsOptionControls     = 4
+	settingsOptionTouch        = 5
+	settingsOptionDisplay      = 6
+	settingsOptionBack         = 7
+	settingsTotalOptions       = 8
+	settingsVolumeOptions      = 2
+
+	settingsStartY     = 130
+	settingsSpacing    = 45
 	settingsButtonSize = 35
 )
 
@@ -43,35 +50,67 @@ type Settings struct {
 	selectedOption int
 	cooldown       int
 	closed         bool
+	screenShake    bool
+	controls       controlsScreen
+	showControls   bool
+	touch          touchLayoutScreen
+	showTouch      bool
+	display        displayScreen
+	showDisplay    bool
 }
 
 func NewSettings() *Settings {
-	return &Settings{}
+	return &Settings{screenShake: true}
 }
 
 func (s *Settings) Draw(screen *ebiten.Image) {
+	if s.showControls {
+		s.controls.Draw(screen)
+		return
+	}
+	if s.showTouch {
+		s.touch.Draw(screen)
+		return
+	}
+	if s.showDisplay {
+		s.display.Draw(screen)
+		return
+	}
 	screen.DrawImage(settingsOverlay, nil)
 	s.drawTitle(screen)
 	s.drawOptions(screen)
 }
 
+// DrawView draws the parts of the open page that belong in the view
+// rather than on the canvas.
+func (s *Settings) DrawView(view *ebiten.Image) {
+	if s.showTouch {
+		s.touch.DrawView(view)
+	}
+}
+
 func (s *Settings) drawTitle(screen *ebiten.Image) {
-	titleText := "AUDIO SETTINGS"
+	titleText := "SETTINGS"
 	titleBounds := text.BoundString(assets.FontUi, titleText)
 	titleX := (config.ScreenWidth - titleBounds.Dx()) / 2
 	text.Draw(screen, titleText, assets.FontUi, titleX, 80, colorSettingsWhite)
 }
 
 func (s *Settings) drawOptions(screen *ebiten.Image) {
-	s.drawVolumeOption(screen, settingsOptionMasterVolume, "Master Volume", assets.GetMasterVolume(), settingsStartY)
-	s.drawVolumeOption(screen, settingsOptionSFXVolume, "SFX Volume", assets.GetSFXVolume(), settingsStartY+settingsSpacing)
-	s.drawToggleOption(screen, settingsOptionToggleSFX, settingsStartY+settingsSpacing*2)
-	s.drawBackOption(screen, settingsOptionBack, settingsStartY+settingsSpacing*3+20)
+	for i := 0; i < settingsVolumeOptions; i++ {
+		s.drawVolumeOption(screen, i, settingsStartY+settingsSpacing*i)
+	}
+	s.drawToggleOption(screen, settingsOptionToggleSFX, "Sound Effects", assets.IsSFXEnabled(), settingsStartY+settingsSpacing*settingsOptionToggleSFX)
+	s.drawToggleOption(screen, settingsOptionScreenShake, "Screen Shake", s.screenShake, settingsStartY+settingsSpacing*settingsOptionScreenShake)
+	s.drawSimpleOption(screen, settingsOptionControls, "Controls", settingsStartY+settingsSpacing*settingsOptionControls)
+	s.drawSimpleOption(screen, settingsOptionTouch, "Touch Controls", settingsStartY+settingsSpacing*settingsOptionTouch)
+	s.drawSimpleOption(screen, settingsOptionDisplay, "Display", settingsStartY+settingsSpacing*settingsOptionDisplay)
+	s.drawBackOption(screen, settingsOptionBack, settingsStartY+settingsSpacing*settingsOptionBack+20)
 }
 
-func (s *Settings) drawVolumeOption(screen *ebiten.Image, index int, label string, volume float64, y int) {
+func (s *Settings) drawVolumeOption(screen *ebiten.Image, index int, y int) {
 	optionColor := s.getOptionColor(index)
-	labelText := fmt.Sprintf("%s: %.0f%%", label, volume*100)
+	labelText := s.getVolumeLabelText(index)
 	labelBounds := text.BoundString(assets.FontUi, labelText)
 	labelX := (config.ScreenWidth - labelBounds.Dx()) / 2
 	text.Draw(screen, labelText, assets.FontUi, labelX, y, optionColor)
@@ -85,12 +124,12 @@ func (s *Settings) drawVolumeOption(screen *ebiten.Image, index int, label strin
 	s.drawVolumeButton(screen, plusX, plusY, "+", colorBtnPlus, colorBtnPlusHover)
 }
 
-func (s *Settings) drawToggleOption(screen *ebiten.Image, index int, y int) {
-	sfxStatus := "ON"
-	if !assets.IsSFXEnabled() {
-		sfxStatus = "OFF"
+func (s *Settings) drawToggleOption(screen *ebiten.Image, index int, label string, enabled bool, y int) {
+	status := "ON"
+	if !enabled {
+		status = "OFF"
 	}
-	s.drawSimpleOption(screen, index, fmt.Sprintf("Sound Effects: %s", sfxStatus), y)
+	s.drawSimpleOption(screen, index, fmt.Sprintf("%s: %s", label, status), y)
 }
 
 func (s *Settings) drawBackOption(screen *ebiten.Image, index int, y int) {
@@ -112,7 +151,7 @@ func (s *Settings) getOptionColor(index int) color.Color {
 }
 
 func (s *Settings) drawVolumeButton(screen *ebiten.Image, x, y int, label string, btnColor, hoverColor color.RGBA) {
-	mouseX, mouseY := ebiten.CursorPosition()
+	mouseX, mouseY := display.CursorPosition()
 	finalColor := btnColor
 	if mouseX >= x && mouseX <= x+settingsButtonSize && mouseY >= y && mouseY <= y+settingsButtonSize {
 		finalColor = hoverColor
@@ -132,6 +171,31 @@ func (s *Settings) drawVolumeButton(screen *ebiten.Image, x, y int, label string
 }
 
 func (s *Settings) Update() {
+	if s.showControls {
+		s.controls.Update()
+		if s.controls.closed {
+			s.showControls = false
+			s.cooldown = 10
+		}
+		return
+	}
+	if s.showTouch {
+		s.touch.Update()
+		if s.touch.closed {
+			s.showTouch = false
+			s.cooldown = 10
+		}
+		return
+	}
+	if s.showDisplay {
+		s.display.Update()
+		if s.display.closed {
+			s.showDisplay = false
+			s.cooldown = 10
+		}
+		return
+	}
+
 	if s.cooldown > 0 {
 		s.cooldown--
 		return
@@ -142,31 +206,31 @@ func (s *Settings) Update() {
 }
 
 func (s *Settings) handleKeyboardInput() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	if input.ActionJustPressed(input.ActionBack) {
 		s.closed = true
 		s.cooldown = 10
 		return
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
+	if input.ActionJustPressed(input.ActionMenuUp) {
 		s.moveSelectionUp()
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
+	if input.ActionJustPressed(input.ActionMenuDown) {
 		s.moveSelectionDown()
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) || inpututil.IsKeyJustPressed(ebiten.KeyA) {
+	if input.ActionJustPressed(input.ActionMenuLeft) {
 		s.adjustOption(-0.1)
 		s.cooldown = 5
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) || inpututil.IsKeyJustPressed(ebiten.KeyD) {
+	if input.ActionJustPressed(input.ActionMenuRight) {
 		s.adjustOption(0.1)
 		s.cooldown = 5
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+	if input.ActionJustPressed(input.ActionConfirm) {
 		s.activateOption()
 		s.cooldown = 10
 	}
@@ -197,16 +261,16 @@ func (s *Settings) handleMouseAndTouch() {
 	}
 
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		handleClick(ebiten.CursorPosition())
+		handleClick(display.CursorPosition())
 	}
 
 	if touchIDs := inpututil.AppendJustPressedTouchIDs(nil); len(touchIDs) > 0 {
-		handleClick(ebiten.TouchPosition(touchIDs[0]))
+		handleClick(display.TouchPosition(touchIDs[0]))
 	}
 }
 
 func (s *Settings) handleVolumeButtonClick(x, y int) bool {
-	for i := 0; i < 2; i++ {
+	for i := 0; i < settingsVolumeOptions; i++ {
 		yPos := settingsStartY + settingsSpacing*i
 
 		labelText := s.getVolumeLabelText(i)
@@ -235,7 +299,7 @@ func (s *Settings) handleVolumeButtonClick(x, y int) bool {
 }
 
 func (s *Settings) getVolumeLabelText(index int) string {
-	if index == 0 {
+	if index == settingsOptionMasterVolume {
 		return fmt.Sprintf("Master Volume: %.0f%%", assets.GetMasterVolume()*100)
 	}
 	return fmt.Sprintf("SFX Volume: %.0f%%", assets.GetSFXVolume()*100)
@@ -244,7 +308,7 @@ func (s *Settings) getVolumeLabelText(index int) string {
 func (s *Settings) handleOptionClick(x, y int) {
 	for i := 0; i < settingsTotalOptions; i++ {
 		yPos := settingsStartY + settingsSpacing*i
-		if i == 3 {
+		if i == settingsOptionBack {
 			yPos += 20
 		}
 
@@ -270,17 +334,39 @@ func (s *Settings) activateOption() {
 	switch s.selectedOption {
 	case settingsOptionToggleSFX:
 		assets.ToggleSFX()
+	case settingsOptionScreenShake:
+		s.screenShake = !s.screenShake
+	case settingsOptionControls:
+		s.controls.open()
+		s.showControls = true
+	case settingsOptionTouch:
+		s.touch.open()
+		s.showTouch = true
+	case settingsOptionDisplay:
+		s.display.open()
+		s.showDisplay = true
 	case settingsOptionBack:
 		s.closed = true
 	}
 }
 
+func (s *Settings) ScreenShake() bool {
+	return s.screenShake
+}
+
+func (s *Settings) SetScreenShake(enabled bool) {
+	s.screenShake = enabled
+}
+
 func (s *Settings) IsClosed() bool {
 	return s.closed
 }
 
 func (s *Settings) Reset() {
 	s.closed = false
+	s.showControls = false
+	s.showTouch = false
+	s.showDisplay = false
 	s.selectedOption = 0
 	s.cooldown = 10
 }
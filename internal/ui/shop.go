@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
 	"go-meteor/internal/systems"
 	assets "go-meteor/src/pkg"
 )
@@ -150,16 +153,18 @@ func (s *Shop) SetProgress(progress *systems.PlayerProgress) {
 }
 
 func (s *Shop) loadShopItems(progress *systems.PlayerProgress) {
-	s.Items = []ShopItem{
-		{PowerType: "superpower", Name: "Super Shot", Icon: assets.SuperPowerSprite, Level: progress.GetUpgradeLevel("superpower"), MaxLevel: 5, BonusPerLvl: 2},
-		{PowerType: "shield", Name: "Shield", Icon: assets.ShieldPowerUpSprite, Level: progress.GetUpgradeLevel("shield"), MaxLevel: 5, BonusPerLvl: 2},
-		{PowerType: "slowmotion", Name: "Slow Motion", Icon: assets.ClockPowerUpSprite, Level: progress.GetUpgradeLevel("slowmotion"), MaxLevel: 5, BonusPerLvl: 2},
-		{PowerType: "laser", Name: "Laser Beam", Icon: assets.LaserPowerUpSprite, Level: progress.GetUpgradeLevel("laser"), MaxLevel: 5, BonusPerLvl: 2},
-		{PowerType: "nuke", Name: "Nuke", Icon: assets.NukePowerUpSprite, Level: progress.GetUpgradeLevel("nuke"), MaxLevel: 5, BonusPerLvl: 2},
-		{PowerType: "multiplier", Name: "Multiplier", Icon: assets.MultiplierPowerUpSprite, Level: progress.GetUpgradeLevel("multiplier"), MaxLevel: 5, BonusPerLvl: 2},
-		{PowerType: "coinmagnet", Name: "Coin Magnet", Icon: assets.CoinSprite, Level: progress.GetUpgradeLevel("coinmagnet"), MaxLevel: 1, IsSpecial: true},
-		{PowerType: "doublecoins", Name: "Double Coins", Icon: assets.CoinSprite, Level: progress.GetUpgradeLevel("doublecoins"), MaxLevel: 1, IsSpecial: true},
-		{PowerType: "startboost", Name: "Start with Boost", Icon: assets.SuperPowerSprite, Level: progress.GetUpgradeLevel("startboost"), MaxLevel: 1, IsSpecial: true},
+	s.Items = make([]ShopItem, 0, len(systems.Upgrades))
+	for _, u := range systems.Upgrades {
+		s.Items = append(s.Items, ShopItem{
+			PowerType:   u.ID,
+			Name:        u.Name,
+			Icon:        assets.IconMap[u.Icon],
+			Level:       progress.GetUpgradeLevel(u.ID),
+			MaxLevel:    u.MaxLevel,
+			BonusPerLvl: int(u.BonusPerLevel / time.Second),
+			IsSpecial:   u.IsPerk(),
+			Description: u.Description,
+		})
 	}
 
 	for i := range s.Items {
@@ -168,32 +173,15 @@ func (s *Shop) loadShopItems(progress *systems.PlayerProgress) {
 }
 
 func (s *Shop) loadSkins(progress *systems.PlayerProgress) {
-	skins := []struct {
-		id   string
-		name string
-		icon *ebiten.Image
-		cost int
-	}{
-		{"gray", "Gray", assets.SkinGray, 0},
-		{"green", "Green", assets.SkinGreen, 50},
-		{"yellow", "Yellow", assets.SkinYellow, 50},
-		{"pink", "Pink", assets.SkinPink, 50},
-		{"red", "Red", assets.SkinRed, 50},
-		{"purple", "Purple", assets.SkinPurple, 50},
-		{"black", "Black", assets.SkinBlack, 100},
-		{"gold", "Gold", assets.SkinGold, 250},
-		{"white", "White", assets.SkinWhite, 50},
-	}
-
-	s.Skins = make([]SkinItem, 0, len(skins))
-	for _, skin := range skins {
+	s.Skins = make([]SkinItem, 0, len(systems.Skins))
+	for _, skin := range systems.Skins {
 		s.Skins = append(s.Skins, SkinItem{
-			ID:         skin.id,
-			Name:       skin.name,
-			Icon:       skin.icon,
-			Cost:       skin.cost,
-			IsOwned:    progress.HasSkin(skin.id),
-			IsEquipped: progress.EquippedSkin == skin.id,
+			ID:         skin.ID,
+			Name:       skin.Name,
+			Icon:       assets.SkinMap[skin.ID],
+			Cost:       skin.Cost,
+			IsOwned:    progress.HasSkin(skin.ID),
+			IsEquipped: progress.EquippedSkin == skin.ID,
 		})
 	}
 }
@@ -211,27 +199,11 @@ func (s *Shop) buildAllItems() {
 }
 
 func (s *Shop) calculateItemCost(item *ShopItem) int {
-	if item.Level >= item.MaxLevel {
-		return 0
-	}
-
-	if item.IsSpecial {
-		specialCosts := map[string]int{
-			"coinmagnet":  500,
-			"doublecoins": 250,
-			"startboost":  250,
-		}
-		if cost, ok := specialCosts[item.PowerType]; ok {
-			return cost
-		}
+	upgrade, ok := systems.UpgradeByID(item.PowerType)
+	if !ok {
 		return 0
 	}
-
-	costs := []int{25, 50, 100, 200, 400}
-	if item.Level < len(costs) {
-		return costs[item.Level]
-	}
-	return 0
+	return upgrade.CostAt(item.Level)
 }
 
 func (s *Shop) SetMobile(isMobile bool) {
@@ -261,7 +233,7 @@ func (s *Shop) updateMessageTimer() {
 }
 
 func (s *Shop) updateConfirmDialog() ShopAction {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	if input.ActionJustPressed(input.ActionBack) {
 		s.showConfirmation = false
 		return s.action
 	}
@@ -275,11 +247,11 @@ func (s *Shop) updateConfirmDialog() ShopAction {
 	}
 
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		handleClick(ebiten.CursorPosition())
+		handleClick(display.CursorPosition())
 	}
 
 	if touchIDs := inpututil.AppendJustPressedTouchIDs(nil); len(touchIDs) > 0 {
-		handleClick(ebiten.TouchPosition(touchIDs[0]))
+		handleClick(display.TouchPosition(touchIDs[0]))
 	}
 
 	return s.action
@@ -315,20 +287,20 @@ func (s *Shop) confirmPurchase() {
 }
 
 func (s *Shop) updateKeyboardInput() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	if input.ActionJustPressed(input.ActionBack) {
 		s.action = ShopActionClose
 		return
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyW) {
+	if input.ActionJustPressed(input.ActionMenuUp) {
 		s.moveSelectionUp()
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyS) {
+	if input.ActionJustPressed(input.ActionMenuDown) {
 		s.moveSelectionDown()
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+	if input.ActionJustPressed(input.ActionConfirm) {
 		s.handleItemSelection()
 	}
 }
@@ -392,11 +364,11 @@ func (s *Shop) updateMouseAndTouch() {
 	}
 
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		handleClick(ebiten.CursorPosition())
+		handleClick(display.CursorPosition())
 	}
 
 	if touchIDs := inpututil.AppendJustPressedTouchIDs(nil); len(touchIDs) > 0 {
-		handleClick(ebiten.TouchPosition(touchIDs[0]))
+		handleClick(display.TouchPosition(touchIDs[0]))
 	}
 }
 
@@ -545,7 +517,7 @@ func (s *Shop) drawHint(screen *ebiten.Image) {
 	hintBgOp.GeoM.Translate(0, float64(config.ScreenHeight-32))
 	screen.DrawImage(hintBg, hintBgOp)
 
-	hintText := "ENTER: Select  |  ESC: Close"
+	hintText := fmt.Sprintf("%s: Select  |  %s: Close", input.KeyLabel(input.ActionConfirm), input.KeyLabel(input.ActionBack))
 	if s.isMobile {
 		hintText = "TAP TO SELECT"
 	}
@@ -554,7 +526,7 @@ func (s *Shop) drawHint(screen *ebiten.Image) {
 }
 
 func (s *Shop) drawBackButton(screen *ebiten.Image) {
-	x, y := ebiten.CursorPosition()
+	x, y := display.CursorPosition()
 	btnColor := colorBtnNormal
 	if x >= 10 && x <= 50 && y >= 10 && y <= 50 {
 		btnColor = colorBtnHover
@@ -570,7 +542,7 @@ func (s *Shop) drawBackButton(screen *ebiten.Image) {
 }
 
 func (s *Shop) drawScrollButtons(screen *ebiten.Image) {
-	x, y := ebiten.CursorPosition()
+	x, y := display.CursorPosition()
 	maxItems := len(s.AllItems)
 
 	upColor := s.getScrollButtonColor(x, y, config.ScreenWidth-50, config.ScreenWidth-10, 100, 140, s.scrollOffset > 0)
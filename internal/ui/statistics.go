@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"go-meteor/internal/config"
+	"go-meteor/internal/display"
 	assets "go-meteor/src/pkg"
 	"image/color"
 	"time"
@@ -17,14 +18,21 @@ type Statistics struct {
 	survivalTime      time.Duration
 	wave              int
 	score             int
+	difficulty        config.Difficulty
+	bossRush          *bossRushResult
+	timeAttack        *timeAttackResult
+	dailyDate         string
+	dailyScored       bool
+	coop              *coopResult
 	settingsButton    *IconButton
 	shopButton        *IconButton
 	openSettings      bool
 	openShop          bool
 }
 
-func NewStatistics(meteors, powerUps, wave, score int, survival time.Duration) *Statistics {
+func NewStatistics(meteors, powerUps, wave, score int, survival time.Duration, difficulty config.Difficulty) *Statistics {
 	return &Statistics{
+		difficulty:        difficulty,
 		meteorsDestroyed:  meteors,
 		powerUpsCollected: powerUps,
 		survivalTime:      survival,
@@ -47,6 +55,63 @@ func NewStatistics(meteors, powerUps, wave, score int, survival time.Duration) *
 	}
 }
 
+type bossRushResult struct {
+	defeated int
+	total    int
+	time     time.Duration
+	best     time.Duration
+}
+
+// SetBossRush replaces the wave and survival lines with Boss Rush results.
+func (s *Statistics) SetBossRush(defeated, total int, runTime, best time.Duration) {
+	s.bossRush = &bossRushResult{
+		defeated: defeated,
+		total:    total,
+		time:     runTime,
+		best:     best,
+	}
+}
+
+type timeAttackResult struct {
+	hits    int
+	penalty int
+}
+
+// SetTimeAttack replaces the survival line with the hits taken and the
+// points they cost.
+func (s *Statistics) SetTimeAttack(hits, penalty int) {
+	s.timeAttack = &timeAttackResult{
+		hits:    hits,
+		penalty: penalty,
+	}
+}
+
+// SetDaily replaces the difficulty line with the challenge date.
+func (s *Statistics) SetDaily(date string, scored bool) {
+	s.dailyDate = date
+	s.dailyScored = scored
+}
+
+type coopResult struct {
+	scores  [2]int
+	meteors [2]int
+}
+
+// SetCoop adds a column per player under the team totals.
+func (s *Statistics) SetCoop(scores, meteors [2]int) {
+	s.coop = &coopResult{
+		scores:  scores,
+		meteors: meteors,
+	}
+}
+
+// FormatRunTime formats a run time as mm:ss.hh.
+func FormatRunTime(d time.Duration) string {
+	minutes := int(d.Minutes())
+	seconds := d.Seconds() - float64(minutes*60)
+	return fmt.Sprintf("%02d:%05.2f", minutes, seconds)
+}
+
 func (s *Statistics) Draw(screen *ebiten.Image, startY int) {
 	titleText := "GAME STATISTICS"
 	titleBounds := text.BoundString(assets.FontSmall, titleText)
@@ -63,8 +128,26 @@ func (s *Statistics) Draw(screen *ebiten.Image, startY int) {
 	text.Draw(screen, scoreText, assets.FontSmall, scoreX, statsY, color.White)
 	statsY += lineSpacing
 
+	// Difficulty
+	difficultyText := fmt.Sprintf("Difficulty: %s", s.difficulty)
+	difficultyColor := DifficultyColor(s.difficulty)
+	if s.dailyDate != "" {
+		difficultyText = "Daily Challenge " + s.dailyDate
+		if !s.dailyScored {
+			difficultyText += " (practice)"
+		}
+		difficultyColor = colorMenuGold
+	}
+	difficultyBounds := text.BoundString(assets.FontSmall, difficultyText)
+	difficultyX := (config.ScreenWidth - difficultyBounds.Dx()) / 2
+	text.Draw(screen, difficultyText, assets.FontSmall, difficultyX, statsY, difficultyColor)
+	statsY += lineSpacing
+
 	// Wave
 	waveText := fmt.Sprintf("Waves Completed: %d", s.wave-1)
+	if s.bossRush != nil {
+		waveText = fmt.Sprintf("Bosses Defeated: %d/%d", s.bossRush.defeated, s.bossRush.total)
+	}
 	waveBounds := text.BoundString(assets.FontSmall, waveText)
 	waveX := (config.ScreenWidth - waveBounds.Dx()) / 2
 	text.Draw(screen, waveText, assets.FontSmall, waveX, statsY, color.RGBA{150, 200, 255, 255})
@@ -88,17 +171,46 @@ func (s *Statistics) Draw(screen *ebiten.Image, startY int) {
 	minutes := int(s.survivalTime.Minutes())
 	seconds := int(s.survivalTime.Seconds()) % 60
 	timeText := fmt.Sprintf("Survival Time: %02d:%02d", minutes, seconds)
+	if s.bossRush != nil {
+		timeText = "Rush Time: " + FormatRunTime(s.bossRush.time)
+		if s.bossRush.best > 0 {
+			timeText += "  Best: " + FormatRunTime(s.bossRush.best)
+		}
+	}
+	if s.timeAttack != nil {
+		timeText = fmt.Sprintf("Hits Taken: %d (-%d)", s.timeAttack.hits, s.timeAttack.penalty)
+	}
 	timeBounds := text.BoundString(assets.FontSmall, timeText)
 	timeX := (config.ScreenWidth - timeBounds.Dx()) / 2
 	text.Draw(screen, timeText, assets.FontSmall, timeX, statsY, color.RGBA{100, 255, 100, 255})
 
+	if s.coop != nil {
+		s.drawCoop(screen, statsY+lineSpacing+6, lineSpacing)
+	}
+
 	s.drawSettingsButton(screen)
 	s.drawShopButton(screen)
 }
 
+// drawCoop draws each player's share centered in their half of the screen.
+func (s *Statistics) drawCoop(screen *ebiten.Image, y, lineSpacing int) {
+	colors := [2]color.Color{color.White, color.RGBA{150, 255, 150, 255}}
+	for slot := 0; slot < 2; slot++ {
+		centerX := config.ScreenWidth/4 + slot*config.ScreenWidth/2
+		lines := []string{
+			fmt.Sprintf("P%d Score: %d", slot+1, s.coop.scores[slot]),
+			fmt.Sprintf("Meteors: %d", s.coop.meteors[slot]),
+		}
+		for i, line := range lines {
+			bounds := text.BoundString(assets.FontSmall, line)
+			text.Draw(screen, line, assets.FontSmall, centerX-bounds.Dx()/2, y+i*lineSpacing, colors[slot])
+		}
+	}
+}
+
 func (s *Statistics) drawSettingsButton(screen *ebiten.Image) {
 	btn := s.settingsButton
-	mouseX, mouseY := ebiten.CursorPosition()
+	mouseX, mouseY := display.CursorPosition()
 	isHovered := float64(mouseX) >= btn.x && float64(mouseX) <= btn.x+btn.size &&
 		float64(mouseY) >= btn.y && float64(mouseY) <= btn.y+btn.size
 
@@ -112,7 +224,7 @@ func (s *Statistics) drawSettingsButton(screen *ebiten.Image) {
 
 func (s *Statistics) drawShopButton(screen *ebiten.Image) {
 	btn := s.shopButton
-	mouseX, mouseY := ebiten.CursorPosition()
+	mouseX, mouseY := display.CursorPosition()
 	isHovered := float64(mouseX) >= btn.x && float64(mouseX) <= btn.x+70 &&
 		float64(mouseY) >= btn.y && float64(mouseY) <= btn.y+btn.size
 
@@ -132,14 +244,14 @@ func (s *Statistics) drawShopButton(screen *ebiten.Image) {
 
 func (s *Statistics) CheckSettingsClick() bool {
 	btn := s.settingsButton
-	mouseX, mouseY := ebiten.CursorPosition()
+	mouseX, mouseY := display.CursorPosition()
 	return float64(mouseX) >= btn.x && float64(mouseX) <= btn.x+btn.size &&
 		float64(mouseY) >= btn.y && float64(mouseY) <= btn.y+btn.size
 }
 
 func (s *Statistics) CheckShopClick() bool {
 	btn := s.shopButton
-	mouseX, mouseY := ebiten.CursorPosition()
+	mouseX, mouseY := display.CursorPosition()
 	return float64(mouseX) >= btn.x && float64(mouseX) <= btn.x+70 &&
 		float64(mouseY) >= btn.y && float64(mouseY) <= btn.y+btn.size
 }
@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"go-meteor/internal/config"
+	"go-meteor/internal/display"
+	"go-meteor/internal/input"
+	assets "go-meteor/src/pkg"
+)
+
+const (
+	touchRowJoystickSize = iota
+	touchRowShootSize
+	touchRowOpacity
+	touchRowFloating
+	touchRowAutoFire
+	touchRowLeftHanded
+	touchRowReset
+	touchRowBack
+	touchRows
+
+	touchStartY    = 120
+	touchRowHeight = 32
+	touchSizeStep  = 5.0
+	touchFadeStep  = 0.1
+)
+
+const (
+	touchDragNone = iota
+	touchDragJoystick
+	touchDragShoot
+)
+
+// touchLayoutScreen is the settings page for the on-screen controls. The
+// joystick and shoot button are previewed in place and can be dragged;
+// every change is applied at once through input.SetTouchLayout.
+type touchLayoutScreen struct {
+	row      int
+	cooldown int
+	closed   bool
+
+	joystick    *input.Joystick
+	shootButton *input.ShootButton
+
+	dragging  int
+	dragTouch ebiten.TouchID
+}
+
+func (t *touchLayoutScreen) open() {
+	t.row = 0
+	t.cooldown = 10
+	t.closed = false
+	t.dragging = touchDragNone
+	if t.joystick == nil {
+		t.joystick = input.NewJoystick(0, 0, config.JoystickRadius)
+		t.shootButton = input.NewShootButton(0, 0, config.ShootButtonRadius)
+	}
+	t.refreshPreview()
+}
+
+func (t *touchLayoutScreen) refreshPreview() {
+	layout := input.CurrentTouchLayout()
+	t.joystick.ApplyLayout(layout)
+	t.shootButton.ApplyLayout(layout)
+}
+
+func (t *touchLayoutScreen) Draw(screen *ebiten.Image) {
+	screen.DrawImage(settingsOverlay, nil)
+
+	title := "TOUCH CONTROLS"
+	drawText(screen, title, assets.FontUi, (config.ScreenWidth-measureText(title, assets.FontUi))/2, 60, colorSettingsWhite)
+
+	layout := input.CurrentTouchLayout()
+	labels := [touchRows]string{
+		touchRowJoystickSize: fmt.Sprintf("Joystick Size: %.0f", layout.JoystickRadius),
+		touchRowShootSize:    fmt.Sprintf("Button Size: %.0f", layout.ShootRadius),
+		touchRowOpacity:      fmt.Sprintf("Opacity: %.0f%%", layout.Opacity*100),
+		touchRowFloating:     "Floating Joystick: " + onOff(layout.Floating),
+		touchRowAutoFire:     "Auto-Fire: " + onOff(layout.AutoFire),
+		touchRowLeftHanded:   "Left-Handed: " + onOff(layout.LeftHanded),
+		touchRowReset:        "RESET TO DEFAULTS",
+		touchRowBack:         "BACK",
+	}
+	for row, label := range labels {
+		optionColor := colorSettingsGray
+		if t.row == row {
+			optionColor = colorSettingsGold
+		}
+		drawText(screen, label, assets.FontSmall, (config.ScreenWidth-measureText(label, assets.FontSmall))/2, touchRowY(row), optionColor)
+	}
+
+	hint := fmt.Sprintf("Drag to move  |  %s/%s: Adjust  |  %s: Back",
+		input.KeyLabel(input.ActionMenuLeft), input.KeyLabel(input.ActionMenuRight), input.KeyLabel(input.ActionBack))
+	drawText(screen, hint, assets.FontSmall, (config.ScreenWidth-measureText(hint, assets.FontSmall))/2, config.ScreenHeight-25, colorSettingsGray)
+}
+
+// DrawView draws the control previews in the view, where they appear in
+// a run.
+func (t *touchLayoutScreen) DrawView(view *ebiten.Image) {
+	t.joystick.Draw(view)
+	t.shootButton.Draw(view)
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "ON"
+	}
+	return "OFF"
+}
+
+func touchRowY(row int) int {
+	y := touchStartY + touchRowHeight*row
+	if row >= touchRowReset {
+		y += 20
+	}
+	return y
+}
+
+func (t *touchLayoutScreen) Update() {
+	t.refreshPreview()
+	if t.dragging != touchDragNone {
+		t.updateDrag()
+		return
+	}
+	if t.cooldown > 0 {
+		t.cooldown--
+		return
+	}
+
+	t.handleKeyboardInput()
+	t.handleMouseAndTouch()
+}
+
+func (t *touchLayoutScreen) handleKeyboardInput() {
+	switch {
+	case input.ActionJustPressed(input.ActionBack):
+		t.closed = true
+	case input.ActionJustPressed(input.ActionMenuUp):
+		t.row = (t.row + touchRows - 1) % touchRows
+	case input.ActionJustPressed(input.ActionMenuDown):
+		t.row = (t.row + 1) % touchRows
+	case input.ActionJustPressed(input.ActionMenuLeft):
+		t.adjust(-1)
+	case input.ActionJustPressed(input.ActionMenuRight):
+		t.adjust(1)
+	case input.ActionJustPressed(input.ActionConfirm):
+		t.activate()
+	}
+}
+
+func (t *touchLayoutScreen) handleMouseAndTouch() {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := display.ViewCursorPosition()
+		t.press(x, y, -1)
+	}
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := display.ViewTouchPosition(id)
+		t.press(x, y, id)
+		break
+	}
+}
+
+// press starts dragging a previewed control, or else acts on the row
+// under the pointer. Size rows shrink when pressed left of centre. vx
+// and vy are in the view, where the preview is drawn.
+func (t *touchLayoutScreen) press(vx, vy int, touch ebiten.TouchID) {
+	layout := input.CurrentTouchLayout()
+	fx, fy := float64(vx), float64(vy)
+	jx, jy := layout.JoystickPos()
+	sx, sy := layout.ShootPos()
+	switch {
+	case math.Hypot(fx-jx, fy-jy) <= layout.JoystickRadius:
+		t.dragging = touchDragJoystick
+	case math.Hypot(fx-sx, fy-sy) <= layout.ShootRadius:
+		t.dragging = touchDragShoot
+	}
+	if t.dragging != touchDragNone {
+		t.dragTouch = touch
+		return
+	}
+
+	cx, cy := display.ViewToCanvas(fx, fy)
+	x, y := int(cx), int(cy)
+	if x < config.ScreenWidth/4 || x > config.ScreenWidth*3/4 {
+		return
+	}
+	for row := 0; row < touchRows; row++ {
+		rowY := touchRowY(row)
+		if y < rowY-touchRowHeight+6 || y > rowY+6 {
+			continue
+		}
+		t.row = row
+		if row <= touchRowOpacity {
+			if x < config.ScreenWidth/2 {
+				t.adjust(-1)
+			} else {
+				t.adjust(1)
+			}
+			return
+		}
+		t.activate()
+		return
+	}
+}
+
+// updateDrag follows the pointer that grabbed a control until it lets go.
+func (t *touchLayoutScreen) updateDrag() {
+	var x, y int
+	if t.dragTouch < 0 {
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			t.dragging = touchDragNone
+			return
+		}
+		x, y = display.ViewCursorPosition()
+	} else {
+		if inpututil.IsTouchJustReleased(t.dragTouch) {
+			t.dragging = touchDragNone
+			return
+		}
+		x, y = display.ViewTouchPosition(t.dragTouch)
+	}
+
+	layout := input.CurrentTouchLayout()
+	if t.dragging == touchDragJoystick {
+		layout.SetJoystickPos(float64(x), float64(y))
+	} else {
+		layout.SetShootPos(float64(x), float64(y))
+	}
+	t.setLayout(layout)
+}
+
+// adjust steps the selected size or opacity, or flips the selected
+// toggle, in direction dir.
+func (t *touchLayoutScreen) adjust(dir float64) {
+	layout := input.CurrentTouchLayout()
+	switch t.row {
+	case touchRowJoystickSize:
+		layout.JoystickRadius += dir * touchSizeStep
+	case touchRowShootSize:
+		layout.ShootRadius += dir * touchSizeStep
+	case touchRowOpacity:
+		layout.Opacity = math.Round((layout.Opacity+dir*touchFadeStep)*10) / 10
+	case touchRowFloating, touchRowAutoFire, touchRowLeftHanded:
+		t.activate()
+		return
+	default:
+		return
+	}
+	t.setLayout(layout)
+	t.cooldown = 5
+}
+
+func (t *touchLayoutScreen) activate() {
+	layout := input.CurrentTouchLayout()
+	switch t.row {
+	case touchRowFloating:
+		layout.Floating = !layout.Floating
+	case touchRowAutoFire:
+		layout.AutoFire = !layout.AutoFire
+	case touchRowLeftHanded:
+		layout.LeftHanded = !layout.LeftHanded
+	case touchRowReset:
+		layout = input.DefaultTouchLayout()
+	case touchRowBack:
+		t.closed = true
+		return
+	default:
+		return
+	}
+	t.setLayout(layout)
+	t.cooldown = 10
+}
+
+func (t *touchLayoutScreen) setLayout(layout input.TouchLayout) {
+	input.SetTouchLayout(layout)
+	t.refreshPreview()
+}
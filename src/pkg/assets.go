@@ -68,6 +68,17 @@ var ExtraLifePowerUpSprite = mustLoadImage("powers/extralife.png")
 var MultiplierPowerUpSprite = mustLoadImage("powers/multiplier.png")
 var CoinSprite = mustLoadImage("profile/coin.png")
 
+// IconMap resolves the icon keys of the upgrade registry.
+var IconMap = map[string]*ebiten.Image{
+	"superpower": SuperPowerSprite,
+	"shield":     ShieldPowerUpSprite,
+	"clock":      ClockPowerUpSprite,
+	"laser":      LaserPowerUpSprite,
+	"nuke":       NukePowerUpSprite,
+	"multiplier": MultiplierPowerUpSprite,
+	"coin":       CoinSprite,
+}
+
 var ScrollArrow = mustLoadImage("mobile_controls/scroll_arrow.png")
 
 func mustLoadImage(name string) *ebiten.Image {
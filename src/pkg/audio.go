@@ -1,7 +1,6 @@
 package assets
 
 import (
-	"bytes"
 	"io"
 	"log"
 
@@ -14,12 +13,13 @@ const sampleRate = 48000
 var (
 	audioContext *audio.Context
 
-	shootSound     []byte
-	explosionSound []byte
-	powerupSound   []byte
-	coinSound      []byte
-	damageSound    []byte
-	gameoverSound  []byte
+	shootSound     = &sound{volume: 0.2, priority: 0, category: CategoryWeapon}
+	explosionSound = &sound{volume: 0.2, priority: 1, category: CategoryExplosion}
+	powerupSound   = &sound{volume: 0.9, priority: 2, category: CategoryPickup}
+	coinSound      = &sound{volume: 0.6, priority: 1, category: CategoryPickup}
+	damageSound    = &sound{volume: 0.6, priority: 2, category: CategoryAlert}
+	gameoverSound  = &sound{volume: 0.8, priority: 3, category: CategoryAlert}
+	bossPhaseSound = &sound{volume: 0.9, priority: 3, category: CategoryAlert, pitch: 0.5}
 
 	masterVolume = 0.7
 	sfxVolume    = 0.7
@@ -29,12 +29,13 @@ var (
 func InitAudio() {
 	audioContext = audio.NewContext(sampleRate)
 
-	shootSound = loadSoundBytes("sounds/shoot.wav")
-	explosionSound = loadSoundBytes("sounds/explosion.wav")
-	powerupSound = loadSoundBytes("sounds/powerup.wav")
-	coinSound = loadSoundBytes("sounds/coin.wav")
-	damageSound = loadSoundBytes("sounds/damage.wav")
-	gameoverSound = loadSoundBytes("sounds/gameover.wav")
+	shootSound.data = loadSoundBytes("sounds/shoot.wav")
+	explosionSound.data = loadSoundBytes("sounds/explosion.wav")
+	powerupSound.data = loadSoundBytes("sounds/powerup.wav")
+	coinSound.data = loadSoundBytes("sounds/coin.wav")
+	damageSound.data = loadSoundBytes("sounds/damage.wav")
+	gameoverSound.data = loadSoundBytes("sounds/gameover.wav")
+	bossPhaseSound.data = powerupSound.data
 }
 
 func loadSoundBytes(path string) []byte {
@@ -60,43 +61,37 @@ func loadSoundBytes(path string) []byte {
 	return data
 }
 
-func playSound(soundData []byte, volume float64) {
-	if !sfxEnabled || soundData == nil || audioContext == nil {
-		return
-	}
-
-	reader := bytes.NewReader(soundData)
-	player, err := audioContext.NewPlayer(reader)
-	if err != nil {
-		return
-	}
+// The Play functions take the X position of what made the sound, which
+// sets its stereo pan.
 
-	player.SetVolume(volume * sfxVolume * masterVolume)
-	player.Play()
+func PlayShootSound(x float64) {
+	shootSound.play(x)
 }
 
-func PlayShootSound() {
-	playSound(shootSound, 0.2)
+func PlayExplosionSound(x float64) {
+	explosionSound.play(x)
 }
 
-func PlayExplosionSound() {
-	playSound(explosionSound, 0.2)
+func PlayPowerUpSound(x float64) {
+	powerupSound.play(x)
 }
 
-func PlayPowerUpSound() {
-	playSound(powerupSound, 0.9)
+func PlayCoinSound(x float64) {
+	coinSound.play(x)
 }
 
-func PlayCoinSound() {
-	playSound(coinSound, 0.6)
+func PlayDamageSound(x float64) {
+	damageSound.play(x)
 }
 
-func PlayDamageSound() {
-	playSound(damageSound, 0.6)
+func PlayGameOverSound(x float64) {
+	gameoverSound.play(x)
 }
 
-func PlayGameOverSound() {
-	playSound(gameoverSound, 0.8)
+// PlayBossPhaseSound is the power-up chime an octave down, for a boss
+// entering a new phase.
+func PlayBossPhaseSound(x float64) {
+	bossPhaseSound.play(x)
 }
 
 func SetMasterVolume(vol float64) {
@@ -111,6 +106,10 @@ func ToggleSFX() {
 	sfxEnabled = !sfxEnabled
 }
 
+func SetSFXEnabled(enabled bool) {
+	sfxEnabled = enabled
+}
+
 func IsSFXEnabled() bool {
 	return sfxEnabled
 }
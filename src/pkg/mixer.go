@@ -0,0 +1,204 @@
+package assets
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"sync"
+
+	"go-meteor/internal/config"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// SoundCategory groups sounds that share a fixed pool of voices.
+type SoundCategory int
+
+const (
+	CategoryWeapon SoundCategory = iota
+	CategoryExplosion
+	CategoryPickup
+	CategoryAlert
+	categoryCount
+)
+
+var categoryVoices = [categoryCount]int{
+	CategoryWeapon:    3,
+	CategoryExplosion: 6,
+	CategoryPickup:    4,
+	CategoryAlert:     2,
+}
+
+const (
+	// stereoSpread keeps sounds at the screen edges from going fully
+	// into one ear.
+	stereoSpread = 0.7
+
+	pitchVariation  = 0.05
+	volumeVariation = 0.1
+
+	// bytesPerFrame is one 16-bit stereo sample.
+	bytesPerFrame = 4
+)
+
+// sound is decoded PCM and how it is mixed.
+type sound struct {
+	data     []byte
+	volume   float64
+	priority int
+	category SoundCategory
+	// pitch shifts the whole sound; 0 plays it as recorded.
+	pitch float64
+}
+
+// voice is a player kept alive and reused for every sound of its
+// category.
+type voice struct {
+	player   *audio.Player
+	stream   *voiceStream
+	priority int
+	started  uint64
+}
+
+var (
+	voicePools [categoryCount][]*voice
+	voiceClock uint64
+)
+
+// play starts s on a free voice of its category, panned towards x. When
+// every voice is busy the lowest priority one is stolen, oldest first;
+// sounds below every busy voice's priority are dropped.
+func (s *sound) play(x float64) {
+	if !sfxEnabled || s.data == nil || audioContext == nil {
+		return
+	}
+
+	v := s.acquireVoice()
+	if v == nil {
+		return
+	}
+
+	voiceClock++
+	v.priority = s.priority
+	v.started = voiceClock
+
+	v.player.Pause()
+	pitch := 1 + (rand.Float64()*2-1)*pitchVariation
+	if s.pitch > 0 {
+		pitch *= s.pitch
+	}
+	v.stream.reset(s.data, pitch, pan(x))
+	if err := v.player.Rewind(); err != nil {
+		return
+	}
+	v.player.SetVolume(s.volume * (1 + (rand.Float64()*2-1)*volumeVariation) * sfxVolume * masterVolume)
+	v.player.Play()
+}
+
+func (s *sound) acquireVoice() *voice {
+	pool := voicePools[s.category]
+
+	var victim *voice
+	for _, v := range pool {
+		if !v.player.IsPlaying() {
+			return v
+		}
+		if v.priority > s.priority {
+			continue
+		}
+		if victim == nil || v.priority < victim.priority || (v.priority == victim.priority && v.started < victim.started) {
+			victim = v
+		}
+	}
+
+	if len(pool) < categoryVoices[s.category] {
+		stream := &voiceStream{}
+		player, err := audioContext.NewPlayer(stream)
+		if err != nil {
+			return nil
+		}
+		v := &voice{player: player, stream: stream}
+		voicePools[s.category] = append(pool, v)
+		return v
+	}
+	return victim
+}
+
+// pan maps x across the playfield to equal-power gains for the left and
+// right channels, normalised so a centred sound plays at its original level.
+func pan(x float64) [2]float64 {
+	p := clamp(x/config.FieldWidth()*2-1, -1, 1) * stereoSpread
+	angle := (p + 1) * math.Pi / 4
+	return [2]float64{math.Cos(angle) * math.Sqrt2, math.Sin(angle) * math.Sqrt2}
+}
+
+// voiceStream plays 16-bit stereo PCM at a pitch and with per-channel
+// gains. It is read by the audio goroutine, hence the lock.
+type voiceStream struct {
+	mu     sync.Mutex
+	data   []byte
+	pos    float64
+	step   float64
+	gains  [2]float64
+	offset int64
+}
+
+func (s *voiceStream) reset(data []byte, step float64, gains [2]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	s.pos = 0
+	s.offset = 0
+	s.step = step
+	s.gains = gains
+}
+
+func (s *voiceStream) Read(buf []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frames := len(s.data) / bytesPerFrame
+	n := 0
+	for n+bytesPerFrame <= len(buf) {
+		i := int(s.pos)
+		if i+1 >= frames {
+			if n == 0 {
+				return 0, io.EOF
+			}
+			break
+		}
+		frac := s.pos - float64(i)
+		for ch := 0; ch < 2; ch++ {
+			a := float64(s.sample(i, ch))
+			b := float64(s.sample(i+1, ch))
+			v := clamp((a+(b-a)*frac)*s.gains[ch], math.MinInt16, math.MaxInt16)
+			out := int16(v)
+			buf[n+ch*2] = byte(out)
+			buf[n+ch*2+1] = byte(out >> 8)
+		}
+		n += bytesPerFrame
+		s.pos += s.step
+	}
+	s.offset += int64(n)
+	return n, nil
+}
+
+func (s *voiceStream) sample(frame, ch int) int16 {
+	i := frame*bytesPerFrame + ch*2
+	return int16(uint16(s.data[i]) | uint16(s.data[i+1])<<8)
+}
+
+// Seek supports rewinding and asking for the position, which is all
+// audio.Player needs.
+func (s *voiceStream) Seek(offset int64, whence int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch whence {
+	case io.SeekStart:
+		s.offset = offset
+	case io.SeekCurrent:
+		s.offset += offset
+	}
+	s.pos = float64(s.offset/bytesPerFrame) * s.step
+	return s.offset, nil
+}
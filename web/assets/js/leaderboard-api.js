@@ -1,5 +1,7 @@
-let cachedLeaderboard = [];
-let lastFetchTime = 0;
+let cachedLeaderboards = {};
+let lastFetchTimes = {};
+let displayedDifficulty = 'normal';
+let displayedMode = 'classic';
 let gameSessionToken = null;
 let lastScoreSaveTime = 0;
 
@@ -11,10 +13,24 @@ const FRONTEND_VERSION = '0.2.0';
 const RECAPTCHA_TIMEOUT = 5000;
 
 
-async function loadLeaderboard() {
+// Daily boards ignore the difficulty; an empty challenge asks the API for
+// today's board.
+function boardKey(mode, difficulty, challenge = '') {
+  if (mode === 'daily') {
+    return `daily:${challenge}`;
+  }
+  return `${mode}:${difficulty}`;
+}
+
+async function loadLeaderboard(difficulty = displayedDifficulty, mode = displayedMode, challenge = '') {
   const now = Date.now();
+  if (mode === 'daily') {
+    difficulty = 'normal';
+  }
+  const key = boardKey(mode, difficulty, challenge);
+  const cachedLeaderboard = cachedLeaderboards[key] || [];
   
-  if (cachedLeaderboard.length > 0 && (now - lastFetchTime) < CACHE_DURATION) {
+  if (cachedLeaderboard.length > 0 && (now - (lastFetchTimes[key] || 0)) < CACHE_DURATION) {
     return cachedLeaderboard;
   }
   
@@ -23,7 +39,7 @@ async function loadLeaderboard() {
   
   for (let attempt = 0; attempt < maxRetries; attempt++) {
     try {
-      const response = await fetch(API_URL, {
+      const response = await fetch(`${API_URL}?difficulty=${encodeURIComponent(difficulty)}&mode=${encodeURIComponent(mode)}&challenge=${encodeURIComponent(challenge)}`, {
         method: 'GET',
         headers: {
           'Content-Type': 'application/json'
@@ -37,9 +53,9 @@ async function loadLeaderboard() {
       const data = await response.json();
       
       if (data.leaderboard) {
-        cachedLeaderboard = data.leaderboard;
-        lastFetchTime = now;
-        return cachedLeaderboard;
+        cachedLeaderboards[key] = data.leaderboard;
+        lastFetchTimes[key] = now;
+        return data.leaderboard;
       }
       return [];
     } catch (error) {
@@ -56,7 +72,7 @@ async function loadLeaderboard() {
   return [];
 }
 
-async function saveScore(playerName, score, signature, timestamp) {
+async function saveScore(playerName, score, signature, timestamp, replay, difficulty, mode, challenge) {
   if (!playerName || !signature || !timestamp || !gameSessionToken) {
     return false;
   }
@@ -124,7 +140,11 @@ async function saveScore(playerName, score, signature, timestamp) {
         sessionToken: gameSessionToken,
         timestamp: timestamp,
         signature: signature,
-        recaptchaToken: recaptchaToken
+        recaptchaToken: recaptchaToken,
+        replay: replay || null,
+        difficulty: difficulty,
+        mode: mode,
+        challenge: challenge
       })
     });
     
@@ -177,7 +197,7 @@ function escapeHtml(text) {
   return div.innerHTML;
 }
 
-window.updateLeaderboard = async function(playerName, score, signature, timestamp) {
+window.updateLeaderboard = async function(playerName, score, signature, timestamp, replay, difficulty, mode, challenge) {
   if (!gameSessionToken) {
     return false;
   }
@@ -193,9 +213,10 @@ window.updateLeaderboard = async function(playerName, score, signature, timestam
   
   lastScoreSaveTime = now;
   
-  const success = await saveScore(playerName, score, signature, timestamp);
+  const success = await saveScore(playerName, score, signature, timestamp, replay, difficulty, mode, challenge);
   if (success) {
-    lastFetchTime = 0;
+    lastFetchTimes[boardKey(mode, difficulty, challenge)] = 0;
+    lastFetchTimes[boardKey(mode, difficulty)] = 0;
     const leaderboard = await loadLeaderboard();
     updateLeaderboardUI(leaderboard);
     gameSessionToken = null;
@@ -220,10 +241,10 @@ window.initGameSession = function() {
   return gameSessionToken;
 };
 
-window.isTopScore = async function(score) {
+window.isTopScore = async function(score, difficulty, mode, challenge) {
   try {
-    lastFetchTime = 0;
-    const leaderboard = await loadLeaderboard();
+    lastFetchTimes[boardKey(mode, difficulty, challenge)] = 0;
+    const leaderboard = await loadLeaderboard(difficulty, mode, challenge);
     
     if (leaderboard.length < 10) {
       return true;
@@ -241,11 +262,29 @@ document.addEventListener('DOMContentLoaded', async () => {
     window.initGameSession();
   }
   
+  const difficultySelect = document.getElementById('leaderboard-difficulty');
+  if (difficultySelect) {
+    displayedDifficulty = difficultySelect.value;
+    difficultySelect.addEventListener('change', async () => {
+      displayedDifficulty = difficultySelect.value;
+      updateLeaderboardUI(await loadLeaderboard());
+    });
+  }
+  
+  const modeSelect = document.getElementById('leaderboard-mode');
+  if (modeSelect) {
+    displayedMode = modeSelect.value;
+    modeSelect.addEventListener('change', async () => {
+      displayedMode = modeSelect.value;
+      updateLeaderboardUI(await loadLeaderboard());
+    });
+  }
+  
   const leaderboard = await loadLeaderboard();
   updateLeaderboardUI(leaderboard);
   
   setInterval(async () => {
-    lastFetchTime = 0;
+    lastFetchTimes[boardKey(displayedMode, displayedDifficulty)] = 0;
     const leaderboard = await loadLeaderboard();
     updateLeaderboardUI(leaderboard);
   }, 30000);